@@ -0,0 +1,154 @@
+package emailvalidator
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Name of the lists in the ListMatch
+const (
+	ListAllow = "allow"
+	ListDeny  = "deny"
+)
+
+// Kind of the entries in the ListMatch
+const (
+	ListKindAddress         = "address"
+	ListKindDomain          = "domain"
+	ListKindDomainSuffix    = "domain_suffix"
+	ListKindUserNamePattern = "username_pattern"
+)
+
+// AccessList is a user provided list of addresses, domains, domain suffixes (the domain and all its sub domains)
+// and glob patterns on the user name (the syntax is the same as path.Match)
+type AccessList struct {
+	Addresses        []string `json:"addresses,omitempty"`
+	Domains          []string `json:"domains,omitempty"`
+	DomainSuffixes   []string `json:"domain_suffixes,omitempty"`
+	UserNamePatterns []string `json:"username_patterns,omitempty"`
+}
+
+// ListMatch is the entry in the allow or deny list that matched the address
+type ListMatch struct {
+	List  string `json:"list"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+func (l AccessList) normalize() (AccessList, error) {
+	res := AccessList{}
+	for _, a := range l.Addresses {
+		res.Addresses = append(res.Addresses, strings.ToLower(a))
+	}
+	for _, d := range l.Domains {
+		res.Domains = append(res.Domains, strings.ToLower(d))
+	}
+	for _, d := range l.DomainSuffixes {
+		d = strings.TrimPrefix(strings.TrimPrefix(d, "*"), ".")
+		res.DomainSuffixes = append(res.DomainSuffixes, strings.ToLower(d))
+	}
+	for _, p := range l.UserNamePatterns {
+		p = strings.ToLower(p)
+		if _, err := path.Match(p, ""); err != nil {
+			return res, fmt.Errorf("invalid user name pattern %q: %w", p, err)
+		}
+		res.UserNamePatterns = append(res.UserNamePatterns, p)
+	}
+
+	return res, nil
+}
+
+func (l AccessList) match(in *Input) (string, string) {
+	address := strings.ToLower(in.Address)
+	for _, a := range l.Addresses {
+		if a == address {
+			return ListKindAddress, a
+		}
+	}
+
+	domain := strings.ToLower(in.Domain)
+	for _, d := range l.Domains {
+		if d == domain {
+			return ListKindDomain, d
+		}
+	}
+
+	for _, d := range l.DomainSuffixes {
+		if d == domain || strings.HasSuffix(domain, "."+d) {
+			return ListKindDomainSuffix, d
+		}
+	}
+
+	username := strings.ToLower(in.UserName)
+	for _, p := range l.UserNamePatterns {
+		if ok, _ := path.Match(p, username); ok {
+			return ListKindUserNamePattern, p
+		}
+	}
+
+	return "", ""
+}
+
+// AllowList adds the entries to the allow list. an allowed address is never reported as disposable, free
+// provider, role account, gibberish, homograph or typo, and the user name rules of the providers (like the minimum
+// length of gmail.com) are not applied to it. the lists are checked right after the length of the address
+func AllowList(l AccessList) OptionSetter {
+	return func(opt *Options) error {
+		l, err := l.normalize()
+		if err != nil {
+			return err
+		}
+		opt.allowList = append(opt.allowList, l)
+		return nil
+	}
+}
+
+// DenyList adds the entries to the deny list, a denied address has the Denied set to true in the result, or it is
+// rejected with the RejectDenied option. the deny list has priority over the allow list
+func DenyList(l AccessList) OptionSetter {
+	return func(opt *Options) error {
+		l, err := l.normalize()
+		if err != nil {
+			return err
+		}
+		opt.denyList = append(opt.denyList, l)
+		return nil
+	}
+}
+
+// RejectDenied rejects the addresses in the deny list with an error, instead of only setting the Denied in the result
+func RejectDenied() OptionSetter {
+	return func(opt *Options) error {
+		opt.rejectDenied = true
+		return nil
+	}
+}
+
+func isAllowed(res *ValidationResult) bool {
+	return res.ListMatch != nil && res.ListMatch.List == ListAllow
+}
+
+func checkAccessList(_ context.Context, in *Input, res *ValidationResult) error {
+	res.Denied = ValidationStateFalse
+	for _, l := range in.opt.denyList {
+		if kind, value := l.match(in); kind != "" {
+			if in.opt.rejectDenied {
+				return fmt.Errorf("the address is in the deny list (%s %s)", kind, value)
+			}
+			res.Denied = ValidationStateTrue
+			res.ListMatch = &ListMatch{List: ListDeny, Kind: kind, Value: value}
+			return nil
+		}
+	}
+
+	for _, l := range in.opt.allowList {
+		if kind, value := l.match(in); kind != "" {
+			res.ListMatch = &ListMatch{List: ListAllow, Kind: kind, Value: value}
+			return nil
+		}
+	}
+
+	return nil
+}
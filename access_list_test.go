@@ -0,0 +1,71 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessList(t *testing.T) {
+	allow := AllowList(AccessList{
+		Domains:          []string{"10mail.org"},
+		UserNamePatterns: []string{"support*"},
+	})
+	deny := DenyList(AccessList{
+		Addresses:      []string{"BadUser@Gmail.com"},
+		DomainSuffixes: []string{"*.competitor.com"},
+	})
+
+	res, err := Validate("test@10mail.org", allow, deny)
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.Disposable)
+	assert.Equal(t, ValidationStateFalse, res.Denied)
+	assert.Equal(t, &ListMatch{List: ListAllow, Kind: ListKindDomain, Value: "10mail.org"}, res.ListMatch)
+
+	res, err = Validate("support-team@example.com", allow, deny)
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.BlackList)
+	assert.Empty(t, res.BlackListCategory)
+	assert.Equal(t, &ListMatch{List: ListAllow, Kind: ListKindUserNamePattern, Value: "support*"}, res.ListMatch)
+
+	res, err = Validate("baduser@gmail.com", allow, deny)
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.Denied)
+	assert.Equal(t, ValidationStateTrue, res.FreeProvider)
+	assert.Equal(t, &ListMatch{List: ListDeny, Kind: ListKindAddress, Value: "baduser@gmail.com"}, res.ListMatch)
+
+	for _, email := range []string{"john@competitor.com", "support@mail.competitor.com"} {
+		res, err = Validate(email, allow, deny)
+		require.NoError(t, err)
+		assert.Equal(t, ValidationStateTrue, res.Denied)
+		assert.Equal(t, &ListMatch{List: ListDeny, Kind: ListKindDomainSuffix, Value: "competitor.com"}, res.ListMatch)
+	}
+
+	res, err = Validate("john@notcompetitor.com", allow, deny)
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.Denied)
+	assert.Nil(t, res.ListMatch)
+
+	_, err = Validate("john@example.com", AllowList(AccessList{UserNamePatterns: []string{"[a-"}}))
+	require.Error(t, err)
+}
+
+func TestAccessListRejectDenied(t *testing.T) {
+	deny := DenyList(AccessList{Domains: []string{"competitor.com"}})
+
+	_, err := Validate("john@competitor.com", deny, RejectDenied())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deny list")
+
+	res, err := Validate("john@example.com", deny, RejectDenied())
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.Denied)
+
+	_, err = Validate("bob@gmail.com")
+	require.Error(t, err)
+
+	res, err = Validate("bob@gmail.com", AllowList(AccessList{Addresses: []string{"bob@gmail.com"}}))
+	require.NoError(t, err)
+	assert.Equal(t, &ListMatch{List: ListAllow, Kind: ListKindAddress, Value: "bob@gmail.com"}, res.ListMatch)
+}
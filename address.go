@@ -0,0 +1,127 @@
+package emailvalidator
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Address is a validated email address, the zero value is the empty address. it can be used in the json and text
+// decoding and as a database column, the invalid and the empty addresses are rejected when they are decoded or
+// scanned. only the json null and the database NULL are the empty address. the domain is in lower case
+type Address struct {
+	local  string
+	domain string
+	tld    string
+}
+
+// ParseAddress validates the address with the default validator and returns it as an Address. only the validation
+// errors reject the address, the signals in the ValidationResult (like disposable) do not
+func ParseAddress(s string, opts ...OptionSetter) (Address, error) {
+	_, in, err := defaultValidator.validateContext(context.Background(), s, opts)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return Address{local: in.UserName, domain: in.Domain, tld: in.TLD}, nil
+}
+
+// LocalPart returns the part of the address before the @
+func (a Address) LocalPart() string {
+	return a.local
+}
+
+// Domain returns the part of the address after the @
+func (a Address) Domain() string {
+	return a.domain
+}
+
+// TLD returns the top level domain of the address
+func (a Address) TLD() string {
+	return a.tld
+}
+
+// IsZero returns true for the empty address
+func (a Address) IsZero() bool {
+	return a.local == "" && a.domain == ""
+}
+
+// String returns the address, or an empty string for the empty address
+func (a Address) String() string {
+	if a.IsZero() {
+		return ""
+	}
+
+	return a.local + "@" + a.domain
+}
+
+// MarshalText implements the encoding.TextMarshaler
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler, the address is validated and the empty text is an error
+func (a *Address) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		return errors.New("the address is empty")
+	}
+
+	addr, err := ParseAddress(string(b))
+	if err != nil {
+		return err
+	}
+
+	*a = addr
+	return nil
+}
+
+// MarshalJSON json transform for the value, the empty address is null
+func (a Address) MarshalJSON() ([]byte, error) {
+	if a.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON json transform for the value, null is the empty address
+func (a *Address) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*a = Address{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner, the address is validated like the UnmarshalText. NULL is the empty address, use a
+// NULL column for the optional addresses
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Address{}
+		return nil
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		return a.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("can not scan %T into an Address", src)
+}
+
+// Value implements the driver.Valuer, the empty address is NULL
+func (a Address) Value() (driver.Value, error) {
+	if a.IsZero() {
+		return nil, nil
+	}
+
+	return a.String(), nil
+}
@@ -0,0 +1,107 @@
+package emailvalidator
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ encoding.TextMarshaler   = Address{}
+	_ encoding.TextUnmarshaler = (*Address)(nil)
+	_ json.Marshaler           = Address{}
+	_ json.Unmarshaler         = (*Address)(nil)
+	_ sql.Scanner              = (*Address)(nil)
+	_ driver.Valuer            = Address{}
+)
+
+func TestParseAddress(t *testing.T) {
+	a, err := ParseAddress("john.smith@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john.smith", a.LocalPart())
+	assert.Equal(t, "gmail.com", a.Domain())
+	assert.Equal(t, "com", a.TLD())
+	assert.Equal(t, "john.smith@gmail.com", a.String())
+	assert.False(t, a.IsZero())
+
+	_, err = ParseAddress("invalid")
+	assert.Error(t, err)
+	_, err = ParseAddress("user@example.invalid")
+	assert.Error(t, err)
+	_, err = ParseAddress("john.smith@gmail.com", DenyList(AccessList{UserNamePatterns: []string{"["}}))
+	assert.Error(t, err)
+
+	a, err = ParseAddress("John.Smith@Gmail.COM")
+	require.NoError(t, err)
+	assert.Equal(t, "John.Smith@gmail.com", a.String())
+	assert.Equal(t, "com", a.TLD())
+
+	assert.True(t, Address{}.IsZero())
+	assert.Equal(t, "", Address{}.String())
+
+	assert.Error(t, a.UnmarshalText(nil))
+	assert.Error(t, a.UnmarshalText([]byte{}))
+	assert.Error(t, a.UnmarshalText([]byte("user@example.invalid")))
+	assert.Equal(t, "John.Smith@gmail.com", a.String())
+}
+
+func TestAddressJSON(t *testing.T) {
+	type user struct {
+		Email  Address  `json:"email"`
+		Backup *Address `json:"backup"`
+	}
+
+	var u user
+	require.NoError(t, json.Unmarshal([]byte(`{"email": "john.smith@gmail.com", "backup": null}`), &u))
+	assert.Equal(t, "john.smith@gmail.com", u.Email.String())
+	assert.Nil(t, u.Backup)
+
+	b, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"email": "john.smith@gmail.com", "backup": null}`, string(b))
+
+	b, err = json.Marshal(user{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"email": null, "backup": null}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"email": "invalid"}`), &u))
+	assert.Error(t, json.Unmarshal([]byte(`{"email": 12}`), &u))
+	assert.Error(t, json.Unmarshal([]byte(`{"email": ""}`), &u))
+	assert.Error(t, json.Unmarshal([]byte(`{"email": "john.smith@gmail.com", "backup": ""}`), &u))
+
+	var m map[Address]int
+	require.NoError(t, json.Unmarshal([]byte(`{"john.smith@gmail.com": 1}`), &m))
+	assert.Len(t, m, 1)
+}
+
+func TestAddressSQL(t *testing.T) {
+	var a Address
+	require.NoError(t, a.Scan("john.smith@gmail.com"))
+	assert.Equal(t, "gmail.com", a.Domain())
+
+	v, err := a.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "john.smith@gmail.com", v)
+
+	require.NoError(t, a.Scan([]byte("jane.smith@gmail.com")))
+	assert.Equal(t, "jane.smith", a.LocalPart())
+
+	require.NoError(t, a.Scan(nil))
+	assert.True(t, a.IsZero())
+	v, err = a.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	// the scanned addresses are validated, and only NULL is the empty address
+	assert.Error(t, a.Scan("user@example.invalid"))
+	assert.Error(t, a.Scan([]byte("user@example.invalid")))
+	assert.Error(t, a.Scan(""))
+	assert.Error(t, a.Scan([]byte{}))
+	assert.Error(t, a.Scan("invalid"))
+	assert.Error(t, a.Scan(12))
+}
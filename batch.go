@@ -0,0 +1,240 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"sync"
+)
+
+const defaultConcurrency = 8
+
+// BatchResult is the result of one address in a batch, the Index is the position of the address in the input
+type BatchResult struct {
+	Index   int
+	Address string
+	Result  *ValidationResult
+	Err     error
+}
+
+// Concurrency sets the number of the addresses that are validated at the same time in a batch, the default is 8
+func Concurrency(n int) OptionSetter {
+	return func(opt *Options) error {
+		if n < 1 {
+			return errors.New("invalid concurrency")
+		}
+		opt.concurrency = n
+		return nil
+	}
+}
+
+// Unordered returns the results of a batch as they complete, not in the input order
+func Unordered() OptionSetter {
+	return func(opt *Options) error {
+		opt.unordered = true
+		return nil
+	}
+}
+
+// mxCache shares the MX lookups of the addresses on the same domain in a batch, the result of the first lookup is
+// used for all of them. the temporary failures are not kept, the next address on the domain looks it up again
+type mxCache struct {
+	lock    sync.Mutex
+	lookup  func(context.Context, string) error
+	entries map[string]*mxEntry
+}
+
+type mxEntry struct {
+	done chan struct{}
+	err  error
+}
+
+func newMXCache(lookup func(context.Context, string) error) *mxCache {
+	return &mxCache{
+		lookup:  lookup,
+		entries: make(map[string]*mxEntry),
+	}
+}
+
+func (c *mxCache) validate(ctx context.Context, domain string) error {
+	domain = strings.ToLower(domain)
+
+	for {
+		c.lock.Lock()
+		e, ok := c.entries[domain]
+		if !ok {
+			e = &mxEntry{done: make(chan struct{})}
+			c.entries[domain] = e
+		}
+		c.lock.Unlock()
+
+		if !ok {
+			e.err = c.lookup(ctx, domain)
+			if ClassifyMXError(e.err).Temporary() {
+				c.lock.Lock()
+				delete(c.entries, domain)
+				c.lock.Unlock()
+			}
+			close(e.done)
+			return e.err
+		}
+
+		select {
+		case <-e.done:
+			if !ClassifyMXError(e.err).Temporary() {
+				return e.err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ValidateStream validates the addresses from the channel and sends the results to the returned channel, which is
+// closed after the input channel is closed and all the results are sent, or the context is canceled. the results
+// are in the input order, unless the Unordered option is set. the concurrency is set with the Concurrency option and
+// the MX lookups are shared between the addresses on the same domain
+func (v *Validator) ValidateStream(ctx context.Context, addresses <-chan string, opts ...OptionSetter) <-chan BatchResult {
+	out := make(chan BatchResult)
+	go func() {
+		defer close(out)
+		v.batch(ctx, func() (string, bool) {
+			select {
+			case a, ok := <-addresses:
+				return a, ok
+			case <-ctx.Done():
+				return "", false
+			}
+		}, func(r BatchResult) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}, opts)
+	}()
+
+	return out
+}
+
+// ValidateBatch validates the addresses and returns the results in the input order, or in the order they complete
+// with the Unordered option. if the context is canceled, the addresses that are not validated yet are not in the
+// result
+func (v *Validator) ValidateBatch(ctx context.Context, addresses iter.Seq[string], opts ...OptionSetter) []BatchResult {
+	next, stop := iter.Pull(addresses)
+	defer stop()
+
+	var res []BatchResult
+	v.batch(ctx, func() (string, bool) {
+		if ctx.Err() != nil {
+			return "", false
+		}
+		return next()
+	}, func(r BatchResult) bool {
+		res = append(res, r)
+		return true
+	}, opts)
+
+	return res
+}
+
+// batch reads the addresses with next and validates them in the workers, the results are passed to emit in one
+// goroutine. at most twice the concurrency of addresses are pending, so a slow address does not hold all the results
+// in the memory in the ordered mode
+func (v *Validator) batch(ctx context.Context, next func() (string, bool), emit func(BatchResult) bool, opts []OptionSetter) {
+	// an invalid option is reported in the result of each address by the ValidateContext
+	opt := &Options{}
+	for _, set := range [][]OptionSetter{v.opts, opts} {
+		for i := range set {
+			_ = set[i](opt)
+		}
+	}
+
+	concurrency := opt.concurrency
+	if concurrency == 0 {
+		concurrency = defaultConcurrency
+	}
+
+	cache := newMXCache(observeMX(validateMx, opt.observer))
+	opts = append(opts[:len(opts):len(opts)], func(opt *Options) error {
+		opt.mxCache = cache
+		return nil
+	})
+
+	var (
+		wg      sync.WaitGroup
+		pending = make(chan struct{}, 2*concurrency)
+		workers = make(chan struct{}, concurrency)
+		results = make(chan BatchResult)
+	)
+
+	go func() {
+		defer func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for i := 0; ; i++ {
+			select {
+			case pending <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			address, ok := next()
+			if !ok {
+				return
+			}
+
+			workers <- struct{}{}
+			wg.Add(1)
+			go func(i int, address string) {
+				defer wg.Done()
+				r := BatchResult{Index: i, Address: address}
+				r.Result, r.Err = v.ValidateContext(ctx, address, opts...)
+				<-workers
+				results <- r
+			}(i, address)
+		}
+	}()
+
+	var (
+		buffer = make(map[int]BatchResult)
+		want   = 0
+		done   = false
+	)
+	for r := range results {
+		if done {
+			continue
+		}
+
+		if opt.unordered {
+			<-pending
+			done = !emit(r)
+			continue
+		}
+
+		buffer[r.Index] = r
+		for ; !done; want++ {
+			r, ok := buffer[want]
+			if !ok {
+				break
+			}
+			delete(buffer, want)
+			<-pending
+			done = !emit(r)
+		}
+	}
+}
+
+// ValidateStream validates the addresses from the channel with the default validator, see Validator.ValidateStream
+func ValidateStream(ctx context.Context, addresses <-chan string, opts ...OptionSetter) <-chan BatchResult {
+	return defaultValidator.ValidateStream(ctx, addresses, opts...)
+}
+
+// ValidateBatch validates the addresses with the default validator, see Validator.ValidateBatch
+func ValidateBatch(ctx context.Context, addresses iter.Seq[string], opts ...OptionSetter) []BatchResult {
+	return defaultValidator.ValidateBatch(ctx, addresses, opts...)
+}
@@ -0,0 +1,161 @@
+package emailvalidator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchAddresses(n int) []string {
+	var res []string
+	for i := 0; i < n; i++ {
+		res = append(res, fmt.Sprintf("johnsmith%d@gmail.com", i))
+	}
+	res = append(res, "invalid")
+	return res
+}
+
+func TestValidateBatch(t *testing.T) {
+	addresses := batchAddresses(50)
+	res := ValidateBatch(context.Background(), slices.Values(addresses), Concurrency(4))
+	require.Len(t, res, len(addresses))
+	for i := range res {
+		assert.Equal(t, i, res[i].Index)
+		assert.Equal(t, addresses[i], res[i].Address)
+	}
+	assert.Equal(t, ValidationStateTrue, res[0].Result.FreeProvider)
+	assert.Error(t, res[len(res)-1].Err)
+
+	res = ValidateBatch(context.Background(), slices.Values(addresses), Unordered())
+	require.Len(t, res, len(addresses))
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Index < res[j].Index
+	})
+	for i := range res {
+		assert.Equal(t, addresses[i], res[i].Address)
+	}
+
+	res = ValidateBatch(context.Background(), slices.Values(addresses[:2]), Concurrency(0))
+	require.Len(t, res, 2)
+	assert.Error(t, res[0].Err)
+}
+
+func TestValidateBatchOrder(t *testing.T) {
+	v := NewValidator()
+	require.NoError(t, v.Register(NewCheck("slow", PhaseNetwork, func(_ context.Context, in *Input, _ *ValidationResult) error {
+		if in.UserName == "slowuser" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		return nil
+	})))
+
+	addresses := []string{"slowuser@gmail.com", "fastuser1@gmail.com", "fastuser2@gmail.com"}
+	res := v.ValidateBatch(context.Background(), slices.Values(addresses), Concurrency(3))
+	require.Len(t, res, 3)
+	assert.Equal(t, "slowuser@gmail.com", res[0].Address)
+
+	res = v.ValidateBatch(context.Background(), slices.Values(addresses), Concurrency(3), Unordered())
+	require.Len(t, res, 3)
+	assert.Equal(t, "slowuser@gmail.com", res[2].Address)
+}
+
+func TestValidateStream(t *testing.T) {
+	addresses := batchAddresses(20)
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, a := range addresses {
+			in <- a
+		}
+	}()
+
+	var res []BatchResult
+	for r := range ValidateStream(context.Background(), in, Concurrency(3)) {
+		res = append(res, r)
+	}
+	require.Len(t, res, len(addresses))
+	for i := range res {
+		assert.Equal(t, addresses[i], res[i].Address)
+	}
+}
+
+func TestValidateStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan string)
+	out := ValidateStream(ctx, in)
+
+	in <- "johnsmith@gmail.com"
+	r := <-out
+	assert.Equal(t, 0, r.Index)
+	require.NoError(t, r.Err)
+
+	cancel()
+	for range out {
+	}
+}
+
+func TestMXCache(t *testing.T) {
+	var calls int32
+	c := newMXCache(func(_ context.Context, domain string) error {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		if domain == "bad.com" {
+			return &net.DNSError{Err: "no such host", Name: domain, IsNotFound: true}
+		}
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.validate(context.Background(), "Good.com"))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Error(t, c.validate(context.Background(), "bad.com"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestMXCacheTemporary(t *testing.T) {
+	var calls int32
+	c := newMXCache(func(_ context.Context, _ string) error {
+		time.Sleep(10 * time.Millisecond)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+		}
+		return nil
+	})
+
+	var (
+		wg     sync.WaitGroup
+		failed int32
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c.validate(context.Background(), "example.com") != nil {
+				atomic.AddInt32(&failed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&failed))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.NoError(t, c.validate(context.Background(), "example.com"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
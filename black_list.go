@@ -1,40 +1,232 @@
 package emailvalidator
 
+import "strings"
+
+// RoleCategory is the category of a role account
+type RoleCategory string
+
+// Role account categories
+const (
+	RoleAbuse     RoleCategory = "abuse"
+	RoleNoReply   RoleCategory = "no_reply"
+	RoleSales     RoleCategory = "sales"
+	RoleSupport   RoleCategory = "support"
+	RoleAdmin     RoleCategory = "admin"
+	RoleHR        RoleCategory = "hr"
+	RoleBilling   RoleCategory = "billing"
+	RoleInfo      RoleCategory = "info"
+	RoleMarketing RoleCategory = "marketing"
+	RoleTechnical RoleCategory = "technical"
+	RoleSecurity  RoleCategory = "security"
+	RoleLegal     RoleCategory = "legal"
+	RoleList      RoleCategory = "list"
+)
+
 // Initial list is based on Limits on Role-based Addresses: https://eepurl.com/dyil9Y
-var blackList = map[string]bool{
-	"abuse":                  true,
-	"admin":                  true,
-	"billing":                true,
-	"compliance":             true,
-	"devnull":                true,
-	"dns":                    true,
-	"ftp":                    true,
-	"hostmaster":             true,
-	"inoc":                   true,
-	"ispfeedback":            true,
-	"ispsupport":             true,
-	"list-request":           true,
-	"list":                   true,
-	"maildaemon":             true,
-	"noc":                    true,
-	"no-reply":               true,
-	"noreply":                true,
-	"null":                   true,
-	"phish":                  true,
-	"phishing":               true,
-	"postmaster":             true,
-	"privacy":                true,
-	"registrar":              true,
-	"root":                   true,
-	"security":               true,
-	"spam":                   true,
-	"support":                true,
-	"sysadmin":               true,
-	"tech":                   true,
-	"undisclosed-recipients": true,
-	"unsubscribe":            true,
-	"usenet":                 true,
-	"uucp":                   true,
-	"webmaster":              true,
-	"www":                    true,
+// the keys are without the separators (dot, dash, underscore and plus). the words that are also common in the names
+// (like jobs, press and root) or are too short (like hr) are in the wholeRoles
+var blackList = map[string]RoleCategory{
+	"abuse":                 RoleAbuse,
+	"admin":                 RoleAdmin,
+	"billing":               RoleBilling,
+	"compliance":            RoleLegal,
+	"devnull":               RoleNoReply,
+	"dns":                   RoleTechnical,
+	"ftp":                   RoleTechnical,
+	"hostmaster":            RoleTechnical,
+	"inoc":                  RoleTechnical,
+	"ispfeedback":           RoleAbuse,
+	"ispsupport":            RoleSupport,
+	"listrequest":           RoleList,
+	"maildaemon":            RoleNoReply,
+	"noc":                   RoleTechnical,
+	"noreply":               RoleNoReply,
+	"phish":                 RoleSecurity,
+	"phishing":              RoleSecurity,
+	"postmaster":            RoleTechnical,
+	"privacy":               RoleLegal,
+	"registrar":             RoleTechnical,
+	"security":              RoleSecurity,
+	"spam":                  RoleAbuse,
+	"support":               RoleSupport,
+	"sysadmin":              RoleAdmin,
+	"tech":                  RoleTechnical,
+	"undisclosedrecipients": RoleList,
+	"unsubscribe":           RoleList,
+	"usenet":                RoleTechnical,
+	"uucp":                  RoleTechnical,
+	"webmaster":             RoleTechnical,
+	"www":                   RoleTechnical,
+
+	// English
+	"accounting":      RoleBilling,
+	"accounts":        RoleBilling,
+	"administrator":   RoleAdmin,
+	"careers":         RoleHR,
+	"contact":         RoleInfo,
+	"customercare":    RoleSupport,
+	"customerservice": RoleSupport,
+	"donotreply":      RoleNoReply,
+	"enquiries":       RoleInfo,
+	"feedback":        RoleInfo,
+	"helpdesk":        RoleSupport,
+	"hiring":          RoleHR,
+	"humanresources":  RoleHR,
+	"info":            RoleInfo,
+	"information":     RoleInfo,
+	"invoice":         RoleBilling,
+	"invoices":        RoleBilling,
+	"legal":           RoleLegal,
+	"mailerdaemon":    RoleNoReply,
+	"marketing":       RoleMarketing,
+	"newsletter":      RoleMarketing,
+	"noreplies":       RoleNoReply,
+	"notifications":   RoleNoReply,
+	"payments":        RoleBilling,
+	"recruiting":      RoleHR,
+	"recruitment":     RoleHR,
+	"sales":           RoleSales,
+
+	// German
+	"bewerbung":     RoleHR,
+	"buchhaltung":   RoleBilling,
+	"datenschutz":   RoleLegal,
+	"hilfe":         RoleSupport,
+	"impressum":     RoleInfo,
+	"karriere":      RoleHR,
+	"keineantwort":  RoleNoReply,
+	"kontakt":       RoleInfo,
+	"kundendienst":  RoleSupport,
+	"kundenservice": RoleSupport,
+	"rechnung":      RoleBilling,
+	"verkauf":       RoleSales,
+	"vertrieb":      RoleSales,
+	"verwaltung":    RoleAdmin,
+
+	// French
+	"assistance":    RoleSupport,
+	"commercial":    RoleSales,
+	"comptabilite":  RoleBilling,
+	"emploi":        RoleHR,
+	"facturation":   RoleBilling,
+	"juridique":     RoleLegal,
+	"nepasrepondre": RoleNoReply,
+	"recrutement":   RoleHR,
+	"rh":            RoleHR,
+	"serviceclient": RoleSupport,
+	"ventes":        RoleSales,
+
+	// Spanish
+	"administracion":    RoleAdmin,
+	"atencionalcliente": RoleSupport,
+	"ayuda":             RoleSupport,
+	"contacto":          RoleInfo,
+	"empleo":            RoleHR,
+	"facturacion":       RoleBilling,
+	"informacion":       RoleInfo,
+	"noresponder":       RoleNoReply,
+	"rrhh":              RoleHR,
+	"soporte":           RoleSupport,
+	"ventas":            RoleSales,
+
+	// Portuguese
+	"atendimento": RoleSupport,
+	"contato":     RoleInfo,
+	"faturamento": RoleBilling,
+	"financeiro":  RoleBilling,
+	"naoresponda": RoleNoReply,
+	"suporte":     RoleSupport,
+	"vendas":      RoleSales,
+
+	// Italian
+	"amministrazione": RoleAdmin,
+	"assistenza":      RoleSupport,
+	"contatti":        RoleInfo,
+	"fatturazione":    RoleBilling,
+	"informazioni":    RoleInfo,
+	"lavoro":          RoleHR,
+	"nonrispondere":   RoleNoReply,
+	"risorseumane":    RoleHR,
+	"supporto":        RoleSupport,
+	"vendite":         RoleSales,
+
+	// Dutch
+	"administratie":    RoleBilling,
+	"facturatie":       RoleBilling,
+	"klantenservice":   RoleSupport,
+	"nietbeantwoorden": RoleNoReply,
+	"ondersteuning":    RoleSupport,
+	"personeelszaken":  RoleHR,
+	"vacatures":        RoleHR,
+	"verkoop":          RoleSales,
+
+	// Polish
+	"faktury":  RoleBilling,
+	"kadry":    RoleHR,
+	"pomoc":    RoleSupport,
+	"sprzedaz": RoleSales,
+
+	// Turkish
+	"bilgi":    RoleInfo,
+	"destek":   RoleSupport,
+	"iletisim": RoleInfo,
+	"muhasebe": RoleBilling,
+	"satis":    RoleSales,
+
+	// Russian
+	"бухгалтерия": RoleBilling,
+	"инфо":        RoleInfo,
+	"поддержка":   RoleSupport,
+	"продажи":     RoleSales,
+}
+
+// wholeRoles are the role words that are only matched as the whole user name, as a token they are often a part of a
+// personal address (like steve.jobs and tom.root)
+var wholeRoles = map[string]RoleCategory{
+	"hello":  RoleInfo,
+	"help":   RoleSupport,
+	"hr":     RoleHR,
+	"jobs":   RoleHR,
+	"list":   RoleList,
+	"null":   RoleNoReply,
+	"office": RoleInfo,
+	"press":  RoleMarketing,
+	"root":   RoleAdmin,
+}
+
+func isRoleSeparator(r rune) bool {
+	switch r {
+	case '.', '-', '_', '+':
+		return true
+	}
+	return false
+}
+
+// ClassifyRole returns the category of the user name if it is a role account, or an empty string. the tokens are
+// split by the dot, dash, underscore and plus. the whole user name is checked first, then the first two tokens
+// together and then the first token, so "noreply.billing" is a no-reply account and "support-team" is a support
+// account, but the words after a name, like "steve.jobs", are not checked. the ambiguous words like root and hr are
+// only matched as the whole user name
+func ClassifyRole(u string) RoleCategory {
+	u = strings.ToLower(u)
+	tokens := strings.FieldsFunc(u, isRoleSeparator)
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	whole := strings.Join(tokens, "")
+	if c, ok := blackList[whole]; ok {
+		return c
+	}
+	if c, ok := wholeRoles[whole]; ok {
+		return c
+	}
+
+	if len(tokens) > 1 {
+		if c, ok := blackList[tokens[0]+tokens[1]]; ok {
+			return c
+		}
+	}
+
+	return blackList[tokens[0]]
 }
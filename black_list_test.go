@@ -0,0 +1,60 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyRole(t *testing.T) {
+	for u, c := range map[string]RoleCategory{
+		"abuse":           RoleAbuse,
+		"Support-Team":    RoleSupport,
+		"info":            RoleInfo,
+		"sales":           RoleSales,
+		"kontakt":         RoleInfo,
+		"facturacion":     RoleBilling,
+		"noreply.billing": RoleNoReply,
+		"no-reply":        RoleNoReply,
+		"no_reply.sales":  RoleNoReply,
+		"list-request":    RoleList,
+		"mailer-daemon":   RoleNoReply,
+		"humanresources":  RoleHR,
+		"поддержка":       RoleSupport,
+		"john.smith":      "",
+		"alexander":       "",
+		"salesman":        "",
+		// the words after a name are not role accounts, the ambiguous words only as the whole user name
+		"steve.jobs":    "",
+		"michael.press": "",
+		"tom.root":      "",
+		"anna.list":     "",
+		"mary.office":   "",
+		"jo.help":       "",
+		"adele.hello":   "",
+		"john.support":  "",
+		"hr":            RoleHR,
+		"hello":         RoleInfo,
+		"root":          RoleAdmin,
+		"null":          RoleNoReply,
+		"help":          RoleSupport,
+		"Press":         RoleMarketing,
+		"hr.john":       "",
+		"root.beer":     "",
+	} {
+		assert.Equal(t, c, ClassifyRole(u), u)
+	}
+}
+
+func TestBlackListCategory(t *testing.T) {
+	res, err := Validate("support-team@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.BlackList)
+	assert.Equal(t, RoleSupport, res.BlackListCategory)
+
+	res, err = Validate("john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.BlackList)
+	assert.Empty(t, res.BlackListCategory)
+}
@@ -0,0 +1,168 @@
+package emailvalidator
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// cacheFormat is a part of the cache version, change it when a change in the checks changes the results
+const cacheFormat = 1
+
+// cacheVersion is the version of the cache entries, it changes with the embedded data so a data update invalidates
+// the cached results
+var cacheVersion = sync.OnceValue(dataVersion)
+
+// dataVersion is the hash of the embedded data and the tables of the checks, the gibberish model, the role words,
+// the special use domains and the typo domains
+func dataVersion() string {
+	h := sha256.New()
+	data := []string{disposableDomainData, wildDisposableDomainData, freeProviderData, tldsData, confusablesData}
+	for _, d := range data {
+		_, _ = fmt.Fprintf(h, "%d\n%s", len(d), d)
+	}
+	// the maps are printed in the order of the keys
+	_, _ = fmt.Fprintf(h, "%v\n%v\n%v\n%v\n%v\n%v\n%q\n",
+		gibberishBigrams, gibberishWeights, blackList, wholeRoles, specialUse, rejectedSpecialUse, typoDomains)
+
+	return fmt.Sprintf("%d-%s", cacheFormat, hex.EncodeToString(h.Sum(nil))[:16])
+}
+
+// CacheEntry is a cached validation result, the Version is the version of the library data that created it. the
+// entries with another version are ignored
+type CacheEntry struct {
+	Version string            `json:"version"`
+	Result  *ValidationResult `json:"result"`
+}
+
+// Cache stores the validation results, the key is the normalized address and a fingerprint of the options and the
+// checks. the checks are in the fingerprint by their name only, so a custom check that changes its results must change
+// its name too (for example with a version suffix) or use a new cache. only the valid addresses are cached. the errors
+// of the cache do not fail the validation, an error in Get is a miss
+type Cache interface {
+	// Get returns the entry of the key, the second return value is false if there is no entry
+	Get(ctx context.Context, key string) (CacheEntry, bool, error)
+	// Set stores the entry for the key
+	Set(ctx context.Context, key string, entry CacheEntry) error
+}
+
+// ResultCache sets the cache of the validation results
+func ResultCache(c Cache) OptionSetter {
+	return func(opt *Options) error {
+		opt.cache = c
+		return nil
+	}
+}
+
+// cacheKey is the address with the lower case domain and the fingerprint of the options and the checks
+func cacheKey(in *Input, checks []Check) string {
+	o := in.opt
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d %d %d %g %d %q %+v %+v %t %v",
+		o.mxValidation, o.mxValidationTimeout, o.mxForce, o.gibberishThreshold, o.gibberishMinLength,
+		o.protectedDomains, o.allowList, o.denyList, o.rejectDenied, o.specialUse)
+	for i := range checks {
+		_, _ = fmt.Fprintf(h, " %q", checks[i].Name())
+	}
+
+	return in.UserName + "@" + in.Domain + " " + hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+func (r *ValidationResult) clone() *ValidationResult {
+	c := *r
+	if r.ListMatch != nil {
+		m := *r.ListMatch
+		c.ListMatch = &m
+	}
+	if r.TLD != nil {
+		t := *r.TLD
+		c.TLD = &t
+	}
+
+	return &c
+}
+
+// MemoryCache is an in memory LRU cache of the validation results, it is safe for concurrent use
+type MemoryCache struct {
+	lock  sync.Mutex
+	size  int
+	ttl   time.Duration
+	now   func() time.Time
+	items map[string]*list.Element
+	order *list.List
+}
+
+type memoryItem struct {
+	key     string
+	entry   CacheEntry
+	expires time.Time
+}
+
+// NewMemoryCache creates a cache of up to size entries, the least recently used entry is removed when it is full.
+// the entries expire after the ttl. a size less than 1 is no limit and a zero ttl means the entries do not expire
+func NewMemoryCache(size int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		size:  size,
+		ttl:   ttl,
+		now:   time.Now,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Get implements the Cache
+func (c *MemoryCache) Get(_ context.Context, key string) (CacheEntry, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false, nil
+	}
+
+	item := e.Value.(*memoryItem)
+	if c.ttl > 0 && !c.now().Before(item.expires) {
+		c.order.Remove(e)
+		delete(c.items, key)
+		return CacheEntry{}, false, nil
+	}
+
+	c.order.MoveToFront(e)
+	return item.entry, true, nil
+}
+
+// Set implements the Cache
+func (c *MemoryCache) Set(_ context.Context, key string, entry CacheEntry) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	item := &memoryItem{key: key, entry: entry, expires: c.now().Add(c.ttl)}
+	if e, ok := c.items[key]; ok {
+		e.Value = item
+		c.order.MoveToFront(e)
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(item)
+	if c.size > 0 && c.order.Len() > c.size {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.items, last.Value.(*memoryItem).key)
+	}
+
+	return nil
+}
+
+// Len returns the number of the entries in the cache, including the expired entries that are not removed yet
+func (c *MemoryCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.order.Len()
+}
+
+var _ Cache = (*MemoryCache)(nil)
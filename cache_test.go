@@ -0,0 +1,119 @@
+package emailvalidator
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewMemoryCache(2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.Set(ctx, fmt.Sprint(i), CacheEntry{Version: fmt.Sprint(i)}))
+		if i == 1 {
+			// the 0 is used, so the 1 is the least recently used
+			_, ok, _ := c.Get(ctx, "0")
+			assert.True(t, ok)
+		}
+	}
+	assert.Equal(t, 2, c.Len())
+
+	_, ok, _ := c.Get(ctx, "1")
+	assert.False(t, ok)
+	e, ok, err := c.Get(ctx, "2")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "2", e.Version)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = c.Get(ctx, "2")
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCacheKey(t *testing.T) {
+	checks := defaultChecks()
+	in := &Input{UserName: "JohnSmith", Domain: "gmail.com", opt: &Options{}}
+	key := cacheKey(in, checks)
+	assert.Contains(t, key, "JohnSmith@gmail.com ")
+	assert.Equal(t, key, cacheKey(in, checks))
+
+	in.opt = &Options{concurrency: 3}
+	assert.Equal(t, key, cacheKey(in, checks))
+
+	in.opt = &Options{}
+	require.NoError(t, CheckMX(time.Second, false)(in.opt))
+	assert.NotEqual(t, key, cacheKey(in, checks))
+
+	in.opt = &Options{}
+	assert.NotEqual(t, key, cacheKey(in, checks[1:]))
+}
+
+func TestDataVersion(t *testing.T) {
+	v := dataVersion()
+	assert.Equal(t, cacheVersion(), v)
+
+	blackList["cachetest"] = RoleInfo
+	assert.NotEqual(t, v, dataVersion())
+	delete(blackList, "cachetest")
+	assert.Equal(t, v, dataVersion())
+
+	w := gibberishWeights[0]
+	gibberishWeights[0] = 0
+	assert.NotEqual(t, v, dataVersion())
+	gibberishWeights[0] = w
+
+	specialUse["cachetest"] = SpecialUseExample
+	assert.NotEqual(t, v, dataVersion())
+	delete(specialUse, "cachetest")
+}
+
+func TestValidateCache(t *testing.T) {
+	var calls int32
+	v := NewValidator()
+	require.NoError(t, v.Register(NewCheck("count", PhaseNetwork, func(context.Context, *Input, *ValidationResult) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})))
+
+	c := NewMemoryCache(10, 0)
+	res, err := v.Validate("johnsmith@gmail.com", ResultCache(c))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.FreeProvider)
+
+	cached, err := v.Validate("johnsmith@GMAIL.com", ResultCache(c))
+	require.NoError(t, err)
+	assert.Equal(t, res, cached)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// the cached result is a copy
+	cached.TLD.Name = "changed"
+	cached, err = v.Validate("johnsmith@gmail.com", ResultCache(c))
+	require.NoError(t, err)
+	assert.Equal(t, "com", cached.TLD.Name)
+
+	_, err = v.Validate("johnsmith@gmail.com", ResultCache(c), GibberishThreshold(0.5, 5))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// the entries of another version are ignored
+	for key := range c.items {
+		c.items[key].Value.(*memoryItem).entry.Version = "old"
+	}
+	_, err = v.Validate("johnsmith@gmail.com", ResultCache(c))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	_, err = v.Validate("invalid", ResultCache(c))
+	require.Error(t, err)
+	assert.Equal(t, 2, c.Len())
+}
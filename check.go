@@ -0,0 +1,242 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Phase is the stage of the validation pipeline that a check belongs to
+type Phase int
+
+const (
+	// PhaseSyntax is for checks that only look at the address itself
+	PhaseSyntax Phase = iota
+	// PhaseData is for checks based on the embedded (or user provided) data
+	PhaseData
+	// PhaseNetwork is for checks that need the network, like the MX lookup
+	PhaseNetwork
+)
+
+// String returns the name of the phase, syntax, data or network
+func (p Phase) String() string {
+	switch p {
+	case PhaseSyntax:
+		return "syntax"
+	case PhaseData:
+		return "data"
+	case PhaseNetwork:
+		return "network"
+	}
+
+	return "Phase(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Name of the built-in checks, use them to reorder, disable or register before/after a built-in check
+const (
+	CheckNameLength       = "length"
+	CheckNameAccessList   = "access_list"
+	CheckNameSpecialUse   = "special_use"
+	CheckNameTLD          = "tld"
+	CheckNameUserName     = "username"
+	CheckNameDisposable   = "disposable"
+	CheckNameFreeProvider = "free_provider"
+	CheckNameBlackList    = "black_list"
+	CheckNameGibberish    = "gibberish"
+	CheckNameHomograph    = "homograph"
+	CheckNameTypo         = "typo"
+	CheckNameMX           = "mx"
+)
+
+// Input is the parsed address, passed to every check in the pipeline
+type Input struct {
+	Address  string
+	UserName string
+	Domain   string
+	TLD      string
+
+	opt *Options
+}
+
+// CheckFunc is the function called for a check. returning an error means the address is invalid and stops the
+// pipeline, the optional signals (like disposable) should be set in the result instead.
+type CheckFunc func(ctx context.Context, in *Input, res *ValidationResult) error
+
+// Check is a single step in the validation pipeline
+type Check interface {
+	// Name is the unique name of the check in a validator, it is also the identity of the check in the key of the
+	// result cache, so a check that changes its results should change its name
+	Name() string
+	// Phase is the phase of the check, it decide the default position of the check in the pipeline
+	Phase() Phase
+	// Check fills the result, or returns an error if the address is not valid
+	Check(ctx context.Context, in *Input, res *ValidationResult) error
+}
+
+type check struct {
+	name  string
+	phase Phase
+	fn    CheckFunc
+}
+
+func (c *check) Name() string {
+	return c.name
+}
+
+func (c *check) Phase() Phase {
+	return c.phase
+}
+
+func (c *check) Check(ctx context.Context, in *Input, res *ValidationResult) error {
+	return c.fn(ctx, in, res)
+}
+
+// NewCheck creates a new check from a function
+func NewCheck(name string, phase Phase, fn CheckFunc) Check {
+	return &check{
+		name:  name,
+		phase: phase,
+		fn:    fn,
+	}
+}
+
+func checkLength(_ context.Context, in *Input, _ *ValidationResult) error {
+	/*
+		In addition to restrictions on syntax, there is a length limit on
+		email addresses.  That limit is a maximum of 64 characters (octets)
+		in the "local part" (before the "@") and a maximum of 255 characters
+		(octets) in the domain part (after the "@") for a total length of 320
+		characters. However, there is a restriction in RFC 2821 on the length of an
+		address in MAIL and RCPT commands of 256 characters.  Since addresses
+		that do not fit in those fields are not normally useful, the upper
+		limit on address lengths should normally be considered to be 256.
+	*/
+	/*
+		https://www.rfc-editor.org/errata/eid1690
+		I believe erratum ID 1003 is slightly wrong. RFC 2821 places a 256 character
+		limit on the forward-path. But a path is defined as
+		Path = "<" [ A-d-l ":" ] Mailbox ">"
+		So the forward-path will contain at least a pair of angle brackets in addition to the Mailbox.
+		This limits the Mailbox (i.e. the email address) to 254 characters.
+	*/
+	if len(in.Address) > 254 {
+		return errors.New("maximum email address size is 254")
+	}
+
+	if len(in.UserName) > 64 {
+		return errors.New("maximum user name (before @) length is 64")
+	}
+
+	return nil
+}
+
+func checkTLD(_ context.Context, in *Input, res *ValidationResult) error {
+	// the allowed special-use top level domains are not in the IANA list
+	if !isValidTLD(in.TLD) && res.SpecialUse != ValidationStateTrue {
+		return fmt.Errorf("the %s is not valid tld", in.TLD)
+	}
+
+	if info, ok := LookupTLD(in.TLD); ok {
+		res.TLD = &info
+	}
+
+	return nil
+}
+
+func checkUserName(_ context.Context, in *Input, res *ValidationResult) error {
+	// the rules of the providers are not applied to the allowed addresses
+	if isAllowed(res) {
+		return isValidUserName(in.UserName, "")
+	}
+
+	return isValidUserName(in.UserName, in.Domain)
+}
+
+func checkDisposable(_ context.Context, in *Input, res *ValidationResult) error {
+	res.Disposable = ValidationStateFalse
+	if !isAllowed(res) && isDisposable(in.Domain) {
+		res.Disposable = ValidationStateTrue
+	}
+
+	return nil
+}
+
+func checkFreeProvider(_ context.Context, in *Input, res *ValidationResult) error {
+	res.FreeProvider = ValidationStateFalse
+	if !isAllowed(res) && isFreeProvider(in.Domain) {
+		res.FreeProvider = ValidationStateTrue
+	}
+
+	return nil
+}
+
+func checkBlackList(_ context.Context, in *Input, res *ValidationResult) error {
+	res.BlackList = ValidationStateFalse
+	if isAllowed(res) {
+		return nil
+	}
+
+	if c := ClassifyRole(in.UserName); c != "" {
+		res.BlackList = ValidationStateTrue
+		res.BlackListCategory = c
+	}
+
+	return nil
+}
+
+func checkMX(ctx context.Context, in *Input, res *ValidationResult) error {
+	dispOrFree := res.Disposable == ValidationStateTrue || res.FreeProvider == ValidationStateTrue
+	if in.opt.mxValidation != 1 || (dispOrFree && in.opt.mxForce != 1) {
+		return nil
+	}
+
+	res.MXValidation = ValidationStateTrue
+	ctx, cancel := context.WithTimeout(ctx, in.opt.mxValidationTimeout)
+	defer cancel()
+	lookup := observeMX(validateMx, in.opt.observer)
+	if in.opt.mxCache != nil {
+		lookup = in.opt.mxCache.validate
+	}
+	err := lookup(ctx, in.Domain)
+	res.MXStatus = ClassifyMXError(err)
+	if err != nil {
+		res.MXValidation = ValidationStateFalse
+		res.MXError = err
+		in.opt.debug(ctx, "email mx lookup failed", func() []slog.Attr {
+			return []slog.Attr{
+				slog.String("domain", in.Domain),
+				slog.String("status", string(res.MXStatus)),
+				slog.String("error", err.Error()),
+			}
+		})
+	}
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetAttributes(
+			AttributeMXValid.Bool(res.MXValidation == ValidationStateTrue),
+			AttributeMXStatus.String(string(res.MXStatus)),
+		)
+	}
+
+	return nil
+}
+
+func defaultChecks() []Check {
+	return []Check{
+		NewCheck(CheckNameLength, PhaseSyntax, checkLength),
+		NewCheck(CheckNameAccessList, PhaseSyntax, checkAccessList),
+		NewCheck(CheckNameSpecialUse, PhaseSyntax, checkSpecialUse),
+		NewCheck(CheckNameTLD, PhaseSyntax, checkTLD),
+		NewCheck(CheckNameUserName, PhaseSyntax, checkUserName),
+		NewCheck(CheckNameDisposable, PhaseData, checkDisposable),
+		NewCheck(CheckNameFreeProvider, PhaseData, checkFreeProvider),
+		NewCheck(CheckNameBlackList, PhaseData, checkBlackList),
+		NewCheck(CheckNameGibberish, PhaseData, checkGibberish),
+		NewCheck(CheckNameHomograph, PhaseData, checkHomograph),
+		NewCheck(CheckNameTypo, PhaseData, checkTypo),
+		NewCheck(CheckNameMX, PhaseNetwork, checkMX),
+	}
+}
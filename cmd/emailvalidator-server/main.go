@@ -0,0 +1,146 @@
+// Command emailvalidator-server serves the email validation JSON API, see the httpapi package for the endpoints, and
+// optionally the gRPC service in the grpcapi package. with -metrics the Prometheus metrics are served on /metrics.
+//
+//	emailvalidator-server -addr :8080 -rate 10 -burst 100 -grpc-addr :9090 -metrics
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+
+	"github.com/fzerorubigd/emailvalidator"
+	"github.com/fzerorubigd/emailvalidator/grpcapi"
+	pb "github.com/fzerorubigd/emailvalidator/grpcapi/emailvalidatorpb"
+	"github.com/fzerorubigd/emailvalidator/httpapi"
+	"github.com/fzerorubigd/emailvalidator/metrics"
+	"github.com/fzerorubigd/emailvalidator/ratelimit"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "the address to listen on")
+	grpcAddr := flag.String("grpc-addr", "", "the address to serve the gRPC service on, it is disabled if empty")
+	maxBody := flag.Int64("max-body", 1<<20, "the maximum size of the request body in bytes")
+	maxBatch := flag.Int("max-batch", 1000, "the maximum number of the addresses in a batch request")
+	maxConcurrency := flag.Int("max-concurrency", 8, "the maximum number of the addresses of a batch validated at the same time")
+	rate := flag.Float64("rate", 0, "the addresses per second allowed for each client on both HTTP and gRPC, 0 disables the rate limit")
+	burst := flag.Int("burst", 100, "the burst of the addresses allowed for each client, used with -rate")
+	mx := flag.Bool("mx", false, "check the MX record of the domain")
+	mxTimeout := flag.Duration("mx-timeout", 5*time.Second, "the timeout of the MX check")
+	mxForce := flag.Bool("mx-force", false, "check the MX record even for the disposable and free provider domains")
+	drain := flag.Duration("drain", 5*time.Second, "the time between failing the readiness check and the shutdown")
+	withMetrics := flag.Bool("metrics", false, "serve the Prometheus metrics on /metrics")
+	flag.Parse()
+
+	opts := []httpapi.Option{
+		httpapi.MaxBodySize(*maxBody),
+		httpapi.MaxBatchSize(*maxBatch),
+	}
+	grpcOpts := []grpcapi.Option{
+		grpcapi.MaxBatchSize(*maxBatch),
+		grpcapi.MaxConcurrency(*maxConcurrency),
+	}
+	if *rate > 0 {
+		// the limiter is shared, so a client has one budget on both the HTTP and the gRPC
+		l, err := ratelimit.New(*rate, *burst)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, httpapi.WithLimiter(l, nil))
+		grpcOpts = append(grpcOpts, grpcapi.RateLimit(l, nil))
+	}
+	var validation []emailvalidator.OptionSetter
+	if *mx {
+		validation = append(validation, emailvalidator.CheckMX(*mxTimeout, *mxForce))
+	}
+	if *withMetrics {
+		m := metrics.NewObserver("")
+		prometheus.MustRegister(m)
+		validation = append(validation, emailvalidator.Observe(m))
+	}
+	opts = append(opts, httpapi.ValidationOptions(append(validation, emailvalidator.Concurrency(*maxConcurrency))...))
+	grpcOpts = append(grpcOpts, grpcapi.ValidationOptions(validation...))
+
+	h, err := httpapi.NewHandler(opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var handler http.Handler = h
+	if *withMetrics {
+		mux := http.NewServeMux()
+		mux.Handle("/", h)
+		mux.Handle("/metrics", promhttp.Handler())
+		handler = mux
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      time.Minute,
+		MaxHeaderBytes:    16 << 10,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var gs *grpc.Server
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		s, err := grpcapi.New(nil, grpcOpts...)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		gs = grpc.NewServer()
+		pb.RegisterValidatorServiceServer(gs, s)
+		go func() {
+			log.Printf("serving gRPC on %s", *grpcAddr)
+			if err := gs.Serve(lis); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	// done is closed when the servers are stopped, ListenAndServe returns as soon as the shutdown starts
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		h.SetReady(false)
+		time.Sleep(*drain)
+
+		if gs != nil {
+			gs.GracefulStop()
+		}
+
+		shutdown, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdown); err != nil {
+			log.Print(err)
+		}
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-done
+}
@@ -0,0 +1,613 @@
+// Command emailvalidator validates email addresses from the arguments, the standard input or a CSV/TSV file.
+//
+// The exit code is 0 if all the addresses are valid, 1 if any address is invalid (or rejected by the policy) and 2
+// for the usage and the input errors.
+//
+//	emailvalidator user@gmail.com
+//	emailvalidator -mx -output json < addresses.txt
+//	emailvalidator -file users.csv -column email -output csv > checked.csv
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fzerorubigd/emailvalidator"
+)
+
+const (
+	exitValid = iota
+	exitInvalid
+	exitError
+)
+
+// record is one input row, the address is the selected column
+type record struct {
+	address string
+	row     []string
+}
+
+// output is the line written for each address in the json output
+type output struct {
+	Address  string                           `json:"address"`
+	Valid    bool                             `json:"valid"`
+	Error    string                           `json:"error,omitempty"`
+	Result   *emailvalidator.ValidationResult `json:"result,omitempty"`
+	Decision *emailvalidator.Decision         `json:"decision,omitempty"`
+}
+
+type config struct {
+	addresses []string
+	file      string
+	column    string
+	header    bool
+	delimiter string
+	format    string
+	policy    *emailvalidator.Policy
+	opts      []emailvalidator.OptionSetter
+}
+
+func splitList(s string) []string {
+	var res []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+func specialUseKinds(s string) []emailvalidator.SpecialUseKind {
+	var res []emailvalidator.SpecialUseKind
+	for _, k := range splitList(s) {
+		res = append(res, emailvalidator.SpecialUseKind(k))
+	}
+
+	return res
+}
+
+func readAccessList(path string) (emailvalidator.AccessList, error) {
+	var l emailvalidator.AccessList
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return l, err
+	}
+
+	return l, json.Unmarshal(data, &l)
+}
+
+func parseFlags(args []string, stderr io.Writer) (*config, error) {
+	fs := flag.NewFlagSet("emailvalidator", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "usage: emailvalidator [flags] [address ...]")
+		_, _ = fmt.Fprintln(stderr, "the addresses are read from the standard input, one per line, if there is no address and no -file")
+		fs.PrintDefaults()
+	}
+
+	cfg := &config{}
+	fs.StringVar(&cfg.file, "file", "", "CSV or TSV file to read the addresses from, - for the standard input")
+	fs.StringVar(&cfg.column, "column", "1", "the column of the address in the file, the 1 based index or the name in the header")
+	fs.BoolVar(&cfg.header, "header", false, "the first row of the file is the header, it is implied when the -column is a name")
+	fs.StringVar(&cfg.delimiter, "delimiter", "", "the delimiter of the file, the default is tab for .tsv files and comma for the others")
+	fs.StringVar(&cfg.format, "output", "table", "the output format, table, json (JSON lines) or csv")
+	mx := fs.Bool("mx", false, "check the MX record of the domain")
+	mxTimeout := fs.Duration("mx-timeout", 5*time.Second, "the timeout of the MX check")
+	mxForce := fs.Bool("mx-force", false, "check the MX record even for the disposable and free provider domains")
+	gibberish := fs.Float64("gibberish-threshold", 0, "the probability that a user name is considered gibberish, 0 for the default")
+	gibberishMin := fs.Int("gibberish-min-length", 6, "the shortest user name checked for gibberish, used with -gibberish-threshold")
+	protected := fs.String("protect", "", "comma separated domains to protect against homograph imitation")
+	allow := fs.String("allow", "", "JSON file of the allow list (addresses, domains, domain_suffixes and username_patterns)")
+	deny := fs.String("deny", "", "JSON file of the deny list, with the same format as -allow")
+	rejectDenied := fs.Bool("reject-denied", false, "the addresses in the deny list are invalid, not only marked as denied")
+	rejectSpecial := fs.String("reject-special-use", "", "comma separated special-use kinds to reject, all for all of them")
+	allowSpecial := fs.String("allow-special-use", "", "comma separated special-use kinds to accept, all for all of them")
+	concurrency := fs.Int("concurrency", 8, "the number of the addresses validated at the same time")
+	policy := fs.String("policy", "", "JSON policy file, the addresses rejected by the policy are invalid")
+	debug := fs.Bool("debug", false, "log the decision of each check and the DNS errors to the standard error")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *mx {
+		cfg.opts = append(cfg.opts, emailvalidator.CheckMX(*mxTimeout, *mxForce))
+	}
+	if *gibberish != 0 {
+		cfg.opts = append(cfg.opts, emailvalidator.GibberishThreshold(*gibberish, *gibberishMin))
+	}
+	if *protected != "" {
+		cfg.opts = append(cfg.opts, emailvalidator.ProtectedDomains(splitList(*protected)...))
+	}
+	if *allow != "" {
+		l, err := readAccessList(*allow)
+		if err != nil {
+			return nil, fmt.Errorf("allow list: %w", err)
+		}
+		cfg.opts = append(cfg.opts, emailvalidator.AllowList(l))
+	}
+	if *deny != "" {
+		l, err := readAccessList(*deny)
+		if err != nil {
+			return nil, fmt.Errorf("deny list: %w", err)
+		}
+		cfg.opts = append(cfg.opts, emailvalidator.DenyList(l))
+	}
+	if *rejectDenied {
+		cfg.opts = append(cfg.opts, emailvalidator.RejectDenied())
+	}
+	if *rejectSpecial == "all" {
+		cfg.opts = append(cfg.opts, emailvalidator.RejectSpecialUse())
+	} else if *rejectSpecial != "" {
+		cfg.opts = append(cfg.opts, emailvalidator.RejectSpecialUse(specialUseKinds(*rejectSpecial)...))
+	}
+	if *allowSpecial == "all" {
+		cfg.opts = append(cfg.opts, emailvalidator.AllowSpecialUse())
+	} else if *allowSpecial != "" {
+		cfg.opts = append(cfg.opts, emailvalidator.AllowSpecialUse(specialUseKinds(*allowSpecial)...))
+	}
+	if *concurrency < 1 {
+		return nil, errors.New("the concurrency should be at least 1")
+	}
+	cfg.opts = append(cfg.opts, emailvalidator.Concurrency(*concurrency))
+	if *debug {
+		l := slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		cfg.opts = append(cfg.opts, emailvalidator.Logger(l))
+	}
+
+	if *policy != "" {
+		data, err := os.ReadFile(*policy)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.policy, err = emailvalidator.ParsePolicy(data); err != nil {
+			return nil, fmt.Errorf("policy: %w", err)
+		}
+	}
+
+	switch cfg.format {
+	case "table", "json", "csv":
+	default:
+		return nil, fmt.Errorf("output %q is not supported", cfg.format)
+	}
+
+	if cfg.file != "" && fs.NArg() > 0 {
+		return nil, errors.New("the addresses and -file can not be used together")
+	}
+
+	cfg.addresses = fs.Args()
+	return cfg, nil
+}
+
+// fileDelimiter returns the delimiter of the file, based on the extension if it is not set
+func (c *config) fileDelimiter() (rune, error) {
+	switch c.delimiter {
+	case "":
+		if strings.EqualFold(filepath.Ext(c.file), ".tsv") {
+			return '\t', nil
+		}
+		return ',', nil
+	case `\t`, "tab":
+		return '\t', nil
+	}
+
+	if len([]rune(c.delimiter)) != 1 {
+		return 0, fmt.Errorf("invalid delimiter %q", c.delimiter)
+	}
+	return []rune(c.delimiter)[0], nil
+}
+
+// input reads the records from the arguments, the lines of the standard input or a CSV/TSV file
+type input struct {
+	header []string
+	next   func() (record, error)
+	close  func() error
+}
+
+func openInput(cfg *config, stdin io.Reader) (*input, error) {
+	in := &input{close: func() error { return nil }}
+	if len(cfg.addresses) > 0 {
+		i := 0
+		in.next = func() (record, error) {
+			if i == len(cfg.addresses) {
+				return record{}, io.EOF
+			}
+			i++
+			return record{address: cfg.addresses[i-1], row: []string{cfg.addresses[i-1]}}, nil
+		}
+		return in, nil
+	}
+
+	if cfg.file == "" {
+		scanner := bufio.NewScanner(stdin)
+		in.next = func() (record, error) {
+			for scanner.Scan() {
+				if a := strings.TrimSpace(scanner.Text()); a != "" {
+					return record{address: a, row: []string{a}}, nil
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return record{}, err
+			}
+			return record{}, io.EOF
+		}
+		return in, nil
+	}
+
+	comma, err := cfg.fileDelimiter()
+	if err != nil {
+		return nil, err
+	}
+
+	r := stdin
+	if cfg.file != "-" {
+		f, err := os.Open(cfg.file)
+		if err != nil {
+			return nil, err
+		}
+		in.close = f.Close
+		r = f
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	column, err := strconv.Atoi(cfg.column)
+	if err != nil || cfg.header {
+		if in.header, err = cr.Read(); err != nil {
+			_ = in.close()
+			return nil, fmt.Errorf("reading the header: %w", err)
+		}
+		if column, err = selectColumn(in.header, cfg.column); err != nil {
+			_ = in.close()
+			return nil, err
+		}
+	}
+	if column < 1 {
+		_ = in.close()
+		return nil, fmt.Errorf("invalid column %d", column)
+	}
+
+	// without a header the columns are named by their index, the first row is read to count them
+	var pending []string
+	if in.header == nil {
+		if pending, err = cr.Read(); err != nil && err != io.EOF {
+			_ = in.close()
+			return nil, err
+		}
+		for i := range pending {
+			in.header = append(in.header, "column"+strconv.Itoa(i+1))
+		}
+	}
+
+	in.next = func() (record, error) {
+		row := pending
+		pending = nil
+		if row == nil {
+			var err error
+			if row, err = cr.Read(); err != nil {
+				return record{}, err
+			}
+		}
+
+		rec := record{row: row}
+		if column <= len(row) {
+			rec.address = strings.TrimSpace(row[column-1])
+		}
+		return rec, nil
+	}
+	return in, nil
+}
+
+// selectColumn returns the 1 based index of the column, by its index or its name in the header
+func selectColumn(header []string, column string) (int, error) {
+	if i, err := strconv.Atoi(column); err == nil {
+		return i, nil
+	}
+
+	for i := range header {
+		if strings.EqualFold(strings.TrimSpace(header[i]), column) {
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("column %q is not in the header", column)
+}
+
+// resultFields returns the name of the fields of the ValidationResult, the same as its json keys. the fields of the
+// nested structs are flattened, like tld_type for the type in the tld
+func resultFields() []string {
+	return jsonFields(reflect.TypeOf(emailvalidator.ValidationResult{}), "")
+}
+
+func jsonFields(t reflect.Type, prefix string) []string {
+	var res []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		ft := t.Field(i).Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			res = append(res, jsonFields(ft, prefix+name+"_")...)
+			continue
+		}
+		res = append(res, prefix+name)
+	}
+
+	return res
+}
+
+// flatten adds the values of the json object to the map, with the same names as the jsonFields
+func flatten(data []byte, prefix string, values map[string]string) {
+	var raw map[string]json.RawMessage
+	_ = json.Unmarshal(data, &raw)
+	for k, v := range raw {
+		switch {
+		case string(v) == "null":
+		case len(v) > 0 && v[0] == '{':
+			flatten(v, prefix+k+"_", values)
+		default:
+			var s string
+			if err := json.Unmarshal(v, &s); err == nil {
+				values[prefix+k] = s
+				continue
+			}
+			values[prefix+k] = string(v)
+		}
+	}
+}
+
+// resultValues returns the value of the fields of the result as text, in the order of the resultFields. the value
+// is the json value of the field, without the quotes for the strings and empty for the null
+func resultValues(res *emailvalidator.ValidationResult) []string {
+	fields := resultFields()
+	values := make([]string, len(fields))
+	if res == nil {
+		return values
+	}
+
+	data, _ := json.Marshal(res)
+	flat := make(map[string]string)
+	flatten(data, "", flat)
+	for i, f := range fields {
+		values[i] = flat[f]
+	}
+
+	return values
+}
+
+// writer writes the results in one of the output formats
+type writer interface {
+	header(input []string) error
+	write(rec record, out output) error
+	flush() error
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonWriter) header([]string) error {
+	return nil
+}
+
+func (w *jsonWriter) write(_ record, out output) error {
+	return w.enc.Encode(out)
+}
+
+func (w *jsonWriter) flush() error {
+	return nil
+}
+
+// csvWriter writes the input row, with the validation fields appended. the short rows are padded to the width of
+// the header, so the fields are in the same columns
+type csvWriter struct {
+	w      *csv.Writer
+	policy bool
+	width  int
+}
+
+func (w *csvWriter) extra() []string {
+	res := []string{"valid", "error"}
+	if w.policy {
+		res = append(res, "decision")
+	}
+
+	return append(res, resultFields()...)
+}
+
+func (w *csvWriter) header(input []string) error {
+	if input == nil {
+		input = []string{"address"}
+	}
+	w.width = len(input)
+
+	return w.w.Write(append(input[:len(input):len(input)], w.extra()...))
+}
+
+func (w *csvWriter) values(out output) []string {
+	res := []string{strconv.FormatBool(out.Valid), out.Error}
+	if w.policy {
+		d := ""
+		if out.Decision != nil {
+			d = out.Decision.Action.String()
+		}
+		res = append(res, d)
+	}
+
+	return append(res, resultValues(out.Result)...)
+}
+
+func (w *csvWriter) write(rec record, out output) error {
+	row := rec.row[:len(rec.row):len(rec.row)]
+	for len(row) < w.width {
+		row = append(row, "")
+	}
+
+	return w.w.Write(append(row, w.values(out)...))
+}
+
+func (w *csvWriter) flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// tableWriter writes the address and the validation fields in aligned columns
+type tableWriter struct {
+	csvWriter
+	tw *tabwriter.Writer
+}
+
+func (w *tableWriter) header([]string) error {
+	cols := append([]string{"address"}, w.extra()...)
+	_, err := fmt.Fprintln(w.tw, strings.ToUpper(strings.Join(cols, "\t")))
+	return err
+}
+
+func (w *tableWriter) write(rec record, out output) error {
+	cols := append([]string{out.Address}, w.values(out)...)
+	_, err := fmt.Fprintln(w.tw, strings.Join(cols, "\t"))
+	return err
+}
+
+func (w *tableWriter) flush() error {
+	return w.tw.Flush()
+}
+
+func newWriter(format string, policy bool, out io.Writer) writer {
+	switch format {
+	case "json":
+		return &jsonWriter{enc: json.NewEncoder(out)}
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(out), policy: policy}
+	}
+
+	return &tableWriter{
+		csvWriter: csvWriter{policy: policy},
+		tw:        tabwriter.NewWriter(out, 0, 4, 2, ' ', 0),
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cfg, err := parseFlags(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitValid
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	in, err := openInput(cfg, stdin)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	defer in.close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the rows are kept by their index until the result of the address is written
+	var (
+		lock      sync.Mutex
+		rows      = make(map[int]record)
+		addresses = make(chan string)
+		readErr   = make(chan error, 1)
+	)
+	go func() {
+		defer close(addresses)
+		for i := 0; ; i++ {
+			rec, err := in.next()
+			if err != nil {
+				if err != io.EOF {
+					readErr <- err
+				}
+				return
+			}
+
+			lock.Lock()
+			rows[i] = rec
+			lock.Unlock()
+			select {
+			case addresses <- rec.address:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	w := newWriter(cfg.format, cfg.policy != nil, stdout)
+	if err := w.header(in.header); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	code := exitValid
+	for r := range emailvalidator.ValidateStream(ctx, addresses, cfg.opts...) {
+		lock.Lock()
+		rec := rows[r.Index]
+		delete(rows, r.Index)
+		lock.Unlock()
+
+		out := output{Address: r.Address, Valid: r.Err == nil, Result: r.Result}
+		if r.Err != nil {
+			out.Error = r.Err.Error()
+		}
+		if cfg.policy != nil && r.Result != nil {
+			out.Decision = cfg.policy.Evaluate(r.Result)
+			if out.Decision.Action == emailvalidator.ActionReject {
+				out.Valid = false
+			}
+		}
+		if !out.Valid {
+			code = exitInvalid
+		}
+
+		if err := w.write(rec, out); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitError
+		}
+	}
+
+	if err := w.flush(); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	select {
+	case err := <-readErr:
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	default:
+	}
+
+	return code
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
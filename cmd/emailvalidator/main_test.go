@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runTest(t *testing.T, stdin string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(context.Background(), args, strings.NewReader(stdin), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunArgs(t *testing.T) {
+	code, out, _ := runTest(t, "", "johnsmith@gmail.com", "info@mailinator.com")
+	assert.Equal(t, exitValid, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "ADDRESS"))
+	assert.True(t, strings.HasPrefix(lines[1], "johnsmith@gmail.com"))
+	assert.True(t, strings.HasPrefix(lines[2], "info@mailinator.com"))
+
+	code, _, _ = runTest(t, "", "johnsmith@gmail.com", "invalid")
+	assert.Equal(t, exitInvalid, code)
+
+	code, _, stderr := runTest(t, "", "-output", "xml", "johnsmith@gmail.com")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "xml")
+
+	code, _, stderr = runTest(t, "", "-debug", "johnsmith@gmail.com")
+	assert.Equal(t, exitValid, code)
+	assert.Contains(t, stderr, "check=free_provider")
+	assert.NotContains(t, stderr, "johnsmith")
+}
+
+func TestRunStdin(t *testing.T) {
+	code, out, _ := runTest(t, "johnsmith@gmail.com\n\ninvalid\n", "-output", "json")
+	assert.Equal(t, exitInvalid, code)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+
+	var o map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &o))
+	assert.Equal(t, "johnsmith@gmail.com", o["address"])
+	assert.Equal(t, true, o["valid"])
+	assert.Equal(t, true, o["result"].(map[string]interface{})["free_provider"])
+
+	o = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &o))
+	assert.Equal(t, false, o["valid"])
+	assert.NotEmpty(t, o["error"])
+	assert.Nil(t, o["result"])
+}
+
+func TestRunFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "users.tsv")
+	require.NoError(t, os.WriteFile(file, []byte("name\temail\nJohn\tjohnsmith@gmail.com\nSupport\tnoreply@example.com\n"), 0644))
+
+	code, out, _ := runTest(t, "", "-file", file, "-column", "email", "-output", "csv")
+	assert.Equal(t, exitValid, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "name,email,valid,error,free_provider,"))
+	assert.True(t, strings.HasPrefix(lines[1], "John,johnsmith@gmail.com,true,,true,"))
+	assert.True(t, strings.HasPrefix(lines[2], "Support,noreply@example.com,true,"))
+
+	code, out, _ = runTest(t, "johnsmith@gmail.com,x\njanesmith@gmail.com\n", "-file", "-", "-output", "csv")
+	assert.Equal(t, exitValid, code)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "column1,column2,valid,"))
+	assert.True(t, strings.HasPrefix(lines[1], "johnsmith@gmail.com,x,true,"))
+	assert.True(t, strings.HasPrefix(lines[2], "janesmith@gmail.com,,true,"))
+
+	code, out, _ = runTest(t, "", "-file", "-", "-output", "csv")
+	assert.Equal(t, exitValid, code)
+	assert.True(t, strings.HasPrefix(out, "address,valid,"))
+
+	policy := filepath.Join(dir, "policy.json")
+	require.NoError(t, os.WriteFile(policy, []byte(`{"rules":[{"signal":"black_list","action":"reject"}]}`), 0644))
+	code, out, _ = runTest(t, "", "-file", file, "-column", "2", "-header", "-policy", policy, "-output", "csv")
+	assert.Equal(t, exitInvalid, code)
+	assert.Contains(t, out, "noreply@example.com,false,,reject,")
+
+	dir = t.TempDir()
+	allow := filepath.Join(dir, "allow.json")
+	require.NoError(t, os.WriteFile(allow, []byte(`{"domains":["gmail.com"]}`), 0644))
+	code, out, _ = runTest(t, "", "-allow", allow, "-output", "csv", "johnsmith@gmail.com")
+	assert.Equal(t, exitValid, code)
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	fields := make(map[string]string)
+	for i, name := range records[0] {
+		fields[name] = records[1][i]
+	}
+	assert.Equal(t, "com", fields["tld_name"])
+	assert.Equal(t, "generic", fields["tld_type"])
+	assert.Equal(t, "allow", fields["list_match_list"])
+	assert.Equal(t, "gmail.com", fields["list_match_value"])
+	assert.NotContains(t, records[0], "tld")
+
+	code, _, stderr := runTest(t, "", "-file", file, "-column", "mail")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "mail")
+}
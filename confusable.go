@@ -0,0 +1,80 @@
+package emailvalidator
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusablesData is the confusables table of the Unicode TR39 (https://www.unicode.org/reports/tr39/), each line is
+// the code point of a character and the code points of its prototype in hex. it is generated from the confusables.txt
+// of the Unicode by generate_confusables.go
+//
+//go:embed data/confusables.tsv
+var confusablesData string
+
+var (
+	confusablesOnce sync.Once
+	confusables     map[rune]string
+)
+
+func parseCodePoints(s string) (string, bool) {
+	var b strings.Builder
+	for _, f := range strings.Fields(s) {
+		r, err := strconv.ParseUint(f, 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return "", false
+		}
+		b.WriteRune(rune(r))
+	}
+
+	return b.String(), b.Len() > 0
+}
+
+func loadConfusables() {
+	confusables = make(map[rune]string, strings.Count(confusablesData, "\n"))
+	for _, line := range strings.Split(confusablesData, "\n") {
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		from, ok := parseCodePoints(parts[0])
+		to, ok2 := parseCodePoints(parts[1])
+		if !ok || !ok2 {
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(from)
+		confusables[r] = to
+	}
+}
+
+// confusableSkeleton returns the skeleton of the string as defined in the TR39, two strings with the same skeleton
+// are confusable. the skeleton is not meant to be displayed
+func confusableSkeleton(s string) string {
+	confusablesOnce.Do(loadConfusables)
+
+	s = norm.NFD.String(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		// the default ignorable characters are removed
+		if !unicode.IsGraphic(r) && r != utf8.RuneError {
+			continue
+		}
+		if to, ok := confusables[r]; ok {
+			b.WriteString(to)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return norm.NFD.String(b.String())
+}
@@ -0,0 +1,130 @@
+package emailvalidator
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// domainSet is a sorted list of domains embedded as text, one domain per line and optionally tab separated values
+// after it. the offset, the length and the first 8 bytes of the domain in each line are indexed on the first use.
+// the lookup is a binary search on the prefixes, so the data is not copied to the heap and most of the comparisons
+// are on integers. the domains are at most 253 bytes (the generator rejects the longer ones), so their length fits in a
+// byte
+type domainSet struct {
+	once     sync.Once
+	data     string
+	starts   []uint32
+	sizes    []uint8
+	prefixes []uint64
+}
+
+// prefix returns the first 8 bytes of the string as a big endian integer, the order of the prefixes is the same as
+// the order of the strings
+func prefix(s string) uint64 {
+	var p uint64
+	for i := 0; i < 8; i++ {
+		p <<= 8
+		if i < len(s) {
+			p |= uint64(s[i])
+		}
+	}
+
+	return p
+}
+
+func newDomainSet(data string) *domainSet {
+	return &domainSet{data: data}
+}
+
+func (s *domainSet) index() {
+	s.once.Do(func() {
+		n := strings.Count(s.data, "\n") + 1
+		s.starts = make([]uint32, 0, n)
+		s.sizes = make([]uint8, 0, n)
+		s.prefixes = make([]uint64, 0, n)
+		for i := 0; i < len(s.data); {
+			end := strings.IndexByte(s.data[i:], '\n')
+			if end < 0 {
+				end = len(s.data) - i
+			}
+			size := strings.IndexByte(s.data[i:i+end], '\t')
+			if size < 0 {
+				size = end
+			}
+
+			s.starts = append(s.starts, uint32(i))
+			s.sizes = append(s.sizes, uint8(size))
+			s.prefixes = append(s.prefixes, prefix(s.data[i:i+size]))
+			i += end + 1
+		}
+	})
+}
+
+func (s *domainSet) domain(i int) string {
+	start := int(s.starts[i])
+	return s.data[start : start+int(s.sizes[i])]
+}
+
+// values returns the text after the domain in the line i
+func (s *domainSet) values(i int) string {
+	start := int(s.starts[i]) + int(s.sizes[i])
+	end := len(s.data)
+	if i+1 < len(s.starts) {
+		end = int(s.starts[i+1]) - 1
+	}
+	if start >= end {
+		return ""
+	}
+
+	return s.data[start+1 : end]
+}
+
+// lookup returns the values of the domain, the second return value is false if the domain is not in the set
+func (s *domainSet) lookup(domain string) (string, bool) {
+	s.index()
+	p := prefix(domain)
+	lo, hi := 0, len(s.starts)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if s.prefixes[mid] < p || s.prefixes[mid] == p && s.domain(mid) < domain {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	if lo == len(s.starts) || s.domain(lo) != domain {
+		return "", false
+	}
+
+	return s.values(lo), true
+}
+
+func (s *domainSet) contains(domain string) bool {
+	_, ok := s.lookup(domain)
+	return ok
+}
+
+// provenance returns the sources of the domain, the values of the line is the bit mask of the sources
+func (s *domainSet) provenance(domain string) provenance {
+	values, ok := s.lookup(domain)
+	if !ok {
+		return 0
+	}
+
+	p, _ := strconv.ParseUint(values, 10, 32)
+	return provenance(p)
+}
+
+func (s *domainSet) len() int {
+	s.index()
+	return len(s.starts)
+}
+
+func (s *domainSet) each(fn func(domain string)) {
+	s.index()
+	for i := range s.starts {
+		fn(s.domain(i))
+	}
+}
@@ -0,0 +1,77 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainSet(t *testing.T) {
+	s := newDomainSet("a.com\t1\nb.com\t3\nc.com\nd.com\t2")
+	assert.Equal(t, 4, s.len())
+
+	v, ok := s.lookup("b.com")
+	assert.True(t, ok)
+	assert.Equal(t, "3", v)
+
+	v, ok = s.lookup("c.com")
+	assert.True(t, ok)
+	assert.Empty(t, v)
+
+	assert.True(t, s.contains("a.com"))
+	assert.False(t, s.contains("aa.com"))
+	assert.False(t, s.contains("e.com"))
+	assert.False(t, s.contains(""))
+	assert.Equal(t, provenance(2), s.provenance("d.com"))
+	assert.Equal(t, provenance(0), s.provenance("b.co"))
+
+	var all []string
+	s.each(func(d string) {
+		all = append(all, d)
+	})
+	assert.Equal(t, []string{"a.com", "b.com", "c.com", "d.com"}, all)
+
+	assert.False(t, newDomainSet("").contains("a.com"))
+}
+
+func TestEmbeddedData(t *testing.T) {
+	for _, s := range []*domainSet{disposableDomain, wildDisposableDomain, freeProvider, tlds} {
+		assert.NotZero(t, s.len())
+		prev := ""
+		s.each(func(d string) {
+			assert.True(t, prev < d, "%q is not sorted after %q", d, prev)
+			prev = d
+		})
+	}
+}
+
+func BenchmarkIsDisposable(b *testing.B) {
+	domains := []string{"bcaoo.com", "things.10mail.org", "gmail.com", "example.com"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		isDisposable(domains[i%len(domains)])
+	}
+}
+
+func BenchmarkIsFreeProvider(b *testing.B) {
+	domains := []string{"gmail.com", "yahoo.com", "bcaoo.com", "example.com"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		isFreeProvider(domains[i%len(domains)])
+	}
+}
+
+func BenchmarkLookupTLD(b *testing.B) {
+	names := []string{"com", "de", "xn--p1ai", "invalid"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		LookupTLD(names[i%len(names)])
+	}
+}
+
+func BenchmarkDomainSetIndex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newDomainSet(disposableDomainData).index()
+	}
+}
@@ -4,4 +4,4 @@
 // And the data in https://github.com/daveearley/Email-Validation-Tool (MIT License) for the free email providers.
 package emailvalidator
 
-//go:generate go run generate.go
+//go:generate go run generate.go -save-snapshot snapshot
@@ -0,0 +1,212 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileCache is a cache of the validation results in a JSON lines file, each Set appends a line to the file and the
+// last line of a key wins. the entries are kept in memory too, the file is read once in NewFileCache. use Compact to
+// remove the replaced, expired and old version entries from the file. it is safe for concurrent use
+type FileCache struct {
+	lock    sync.Mutex
+	path    string
+	ttl     time.Duration
+	now     func() time.Time
+	file    *os.File
+	entries map[string]fileRecord
+}
+
+type fileRecord struct {
+	Key  string    `json:"key"`
+	Time time.Time `json:"time"`
+	CacheEntry
+}
+
+// NewFileCache opens the cache file, or creates it if it does not exist. the entries expire after the ttl, a zero
+// ttl means the entries do not expire. the lines that are not valid (like a partial write) are ignored
+func NewFileCache(path string, ttl time.Duration) (*FileCache, error) {
+	c := &FileCache{
+		path:    path,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]fileRecord),
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *FileCache) load() error {
+	f, err := os.Open(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var r fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.Key == "" {
+			continue
+		}
+		c.entries[r.Key] = r
+	}
+
+	return scanner.Err()
+}
+
+// open opens the file to append, a partial last line is ended so the next line is not appended to it
+func (c *FileCache) open() error {
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if st.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, st.Size()-1); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if last[0] != '\n' {
+			if _, err := f.Write([]byte{'\n'}); err != nil {
+				_ = f.Close()
+				return err
+			}
+		}
+	}
+
+	c.file = f
+	return nil
+}
+
+func (c *FileCache) expired(r fileRecord) bool {
+	return c.ttl > 0 && !c.now().Before(r.Time.Add(c.ttl))
+}
+
+// Get implements the Cache
+func (c *FileCache) Get(_ context.Context, key string) (CacheEntry, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	r, ok := c.entries[key]
+	if !ok || c.expired(r) {
+		return CacheEntry{}, false, nil
+	}
+
+	return r.CacheEntry, true, nil
+}
+
+// Set implements the Cache
+func (c *FileCache) Set(_ context.Context, key string, entry CacheEntry) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.file == nil {
+		return os.ErrClosed
+	}
+
+	r := fileRecord{Key: key, Time: c.now(), CacheEntry: entry}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	c.entries[key] = r
+	return nil
+}
+
+// Compact rewrites the file with only the live entries of the current version
+func (c *FileCache) Compact() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.file == nil {
+		return os.ErrClosed
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".emailvalidator-cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for key, r := range c.entries {
+		if c.expired(r) || r.Version != cacheVersion() {
+			delete(c.entries, key)
+			continue
+		}
+		if err := enc.Encode(r); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := c.file.Close(); err != nil {
+		return err
+	}
+	c.file = nil
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		_ = c.open()
+		return err
+	}
+
+	return c.open()
+}
+
+// Close closes the file, the cache can not be used after the close
+func (c *FileCache) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.file == nil {
+		return os.ErrClosed
+	}
+
+	err := c.file.Close()
+	c.file = nil
+	return err
+}
+
+var _ Cache = (*FileCache)(nil)
@@ -0,0 +1,80 @@
+package emailvalidator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCache(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+
+	c, err := NewFileCache(path, time.Hour)
+	require.NoError(t, err)
+
+	var fail atomic.Bool
+	v := NewValidator()
+	require.NoError(t, v.Register(NewCheck("fail", PhaseNetwork, func(context.Context, *Input, *ValidationResult) error {
+		if fail.Load() {
+			return assert.AnError
+		}
+		return nil
+	})))
+	res, err := v.Validate("johnsmith@gmail.com", ResultCache(c))
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "old", CacheEntry{Version: "old", Result: res}))
+	require.NoError(t, c.Set(ctx, "expired", CacheEntry{Version: cacheVersion(), Result: res}))
+	require.NoError(t, c.Close())
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"key":"partial","ver`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	c, err = NewFileCache(path, time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, c.entries, 3)
+	require.NoError(t, c.Set(ctx, "after", CacheEntry{Version: "old"}))
+	require.NoError(t, c.Close())
+
+	// the partial line does not break the line after it
+	c, err = NewFileCache(path, time.Hour)
+	require.NoError(t, err)
+	defer c.Close()
+	assert.Len(t, c.entries, 4)
+
+	fail.Store(true)
+	cached, err := v.Validate("johnsmith@gmail.com", ResultCache(c))
+	require.NoError(t, err)
+	assert.Equal(t, res, cached)
+
+	r := c.entries["expired"]
+	r.Time = r.Time.Add(-2 * time.Hour)
+	c.entries["expired"] = r
+	_, ok, err := c.Get(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, c.Compact())
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(b), "\n"))
+	assert.Len(t, c.entries, 1)
+
+	require.NoError(t, c.Set(ctx, "new", CacheEntry{Version: cacheVersion(), Result: res}))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(b), "\n"))
+
+	require.NoError(t, c.Close())
+	assert.Error(t, c.Set(ctx, "closed", CacheEntry{}))
+}
@@ -1,109 +1,437 @@
-// +build ignore
+//go:build ignore
 
 package main
 
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"go/format"
 	"go/parser"
 	"go/token"
+	"html"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
+
+	"golang.org/x/net/idna"
+
+	"github.com/fzerorubigd/emailvalidator/internal/datagen"
 )
 
-func getURLStrings(url string) ([]string, error) {
-	resp, err := http.Get(url)
+// fetchDateFile is the file in the snapshot directory that keeps the fetch date
+const fetchDateFile = "FETCH_DATE"
+
+// rootZoneRow matches a row in the IANA root zone database (https://www.iana.org/domains/root/db), the groups are
+// the ascii name of the tld, the type and the sponsoring organisation
+var rootZoneRow = regexp.MustCompile(`(?s)<a href="/domains/root/db/([^"]+)\.html">.*?</a>\s*</span>\s*</td>\s*<td>([^<]*)</td>\s*<td>([^<]*)</td>`)
+
+// idnCountries maps the IDN country code top level domains to the ISO 3166 code of the country
+var idnCountries = map[string]string{
+	"xn--2scrj9c":            "IN",
+	"xn--3e0b707e":           "KR",
+	"xn--3hcrj9c":            "IN",
+	"xn--45br5cyl":           "IN",
+	"xn--45brj9c":            "IN",
+	"xn--54b7fta0cc":         "BD",
+	"xn--80ao21a":            "KZ",
+	"xn--90a3ac":             "RS",
+	"xn--90ae":               "BG",
+	"xn--90ais":              "BY",
+	"xn--clchc0ea0b2g2a9gcd": "SG",
+	"xn--d1alf":              "MK",
+	"xn--e1a4c":              "EU",
+	"xn--fiqs8s":             "CN",
+	"xn--fiqz9s":             "CN",
+	"xn--fpcrj9c3d":          "IN",
+	"xn--fzc2c9e2c":          "LK",
+	"xn--gecrj9c":            "IN",
+	"xn--h2breg3eve":         "IN",
+	"xn--h2brj9c":            "IN",
+	"xn--h2brj9c8c":          "IN",
+	"xn--j1amh":              "UA",
+	"xn--j6w193g":            "HK",
+	"xn--kprw13d":            "TW",
+	"xn--kpry57d":            "TW",
+	"xn--l1acc":              "MN",
+	"xn--lgbbat1ad8j":        "DZ",
+	"xn--mgb9awbf":           "OM",
+	"xn--mgba3a4f16a":        "IR",
+	"xn--mgbaam7a8h":         "AE",
+	"xn--mgbah1a3hjkrd":      "MR",
+	"xn--mgbai9azgqp6j":      "PK",
+	"xn--mgbayh7gpa":         "JO",
+	"xn--mgbbh1a":            "IN",
+	"xn--mgbbh1a71e":         "IN",
+	"xn--mgbc0a9azcg":        "MA",
+	"xn--mgberp4a5d4ar":      "SA",
+	"xn--mgbgu82a":           "IN",
+	"xn--mgbpl2fh":           "SD",
+	"xn--mgbtx2b":            "IQ",
+	"xn--mgbx4cd0ab":         "MY",
+	"xn--mix891f":            "MO",
+	"xn--node":               "GE",
+	"xn--o3cw4h":             "TH",
+	"xn--ogbpf8fl":           "SY",
+	"xn--p1ai":               "RU",
+	"xn--pgbs0dh":            "TN",
+	"xn--qxa6a":              "EU",
+	"xn--qxam":               "GR",
+	"xn--rvc1e0am3e":         "IN",
+	"xn--s9brj9c":            "IN",
+	"xn--wgbh1c":             "EG",
+	"xn--wgbl6a":             "QA",
+	"xn--xkc2al3hye2a":       "LK",
+	"xn--xkc2dl3a5ee0h":      "IN",
+	"xn--y9a3aq":             "AM",
+	"xn--yfro4i67o":          "SG",
+	"xn--ygbi2ammx":          "PS",
+}
+
+// countryExceptions is for the country code top level domains that are not the ISO 3166 code of the country
+var countryExceptions = map[string]string{
+	"uk": "GB",
+}
+
+type rootZoneEntry struct {
+	Type    string
+	Sponsor string
+}
+
+type tldInfo struct {
+	Name    string
+	Type    string
+	Sponsor string
+	Country string
+	Unicode string
+}
+
+// source is an upstream data file, it is read from the local path or the snapshot directory if they are set,
+// otherwise it is fetched from the url. the name is recorded as the provenance of the domains in this source
+type source struct {
+	Name     string `json:"name"`
+	File     string `json:"file"`
+	URL      string `json:"url"`
+	Format   string `json:"format"`
+	Path     string `json:"-"`
+	Optional bool   `json:"-"`
+
+	data []byte
+}
+
+// config is the list of the sources for each category (disposable, wildcard, free and tlds)
+type config map[string][]*source
+
+// categories are the lists that are generated from the sources in the config, in order, the variable names and the
+// embedded files in the data directory
+var categories = []struct {
+	Name       string
+	Var        string
+	File       string
+	Provenance bool
+}{
+	{Name: "disposable", Var: "disposableDomain", File: "disposable.tsv", Provenance: true},
+	{Name: "wildcard", Var: "wildDisposableDomain", File: "wildcard.tsv", Provenance: true},
+	{Name: "free", Var: "freeProvider", File: "free.tsv", Provenance: true},
+	{Name: "tlds", Var: "tlds", File: "tlds.tsv"},
+}
+
+// pathFlags is the -source flag, file=path pairs
+type pathFlags map[string]string
+
+func (p pathFlags) String() string {
+	return fmt.Sprint(map[string]string(p))
+}
+
+func (p pathFlags) Set(v string) error {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid source %q, it should be file=path", v)
+	}
+	p[parts[0]] = parts[1]
+	return nil
+}
+
+func loadConfig(path string) (config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data []string
-	reader := bufio.NewReader(resp.Body)
-	for {
-		s, err := reader.ReadString('\n')
-		if err != nil && err != io.EOF {
-			return nil, err
-		}
+	cfg := config{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
 
-		if err == io.EOF {
-			break
+	names := make(map[string]bool)
+	for _, c := range categories {
+		if len(cfg[c.Name]) == 0 {
+			return nil, fmt.Errorf("there is no source for %s", c.Name)
 		}
-
-		s = strings.Trim(s, "\n\t ")
-		if len(s) < 1 {
-			continue
+		for _, s := range cfg[c.Name] {
+			if s.Name == "" || s.File == "" {
+				return nil, fmt.Errorf("the name and the file are required for the sources in %s", c.Name)
+			}
+			if names[s.File] {
+				return nil, fmt.Errorf("the file %s is used more than once", s.File)
+			}
+			names[s.File] = true
 		}
+	}
 
-		if s[0] != '#' {
-			data = append(data, strings.ToLower(strings.Trim(s, "',")))
-		}
+	return cfg, nil
+}
+
+// readOverride reads an override file, the file is optional
+func readOverride(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return data, nil
+	return datagen.ParseLines(data)
+}
 
+// merge returns the union of the sources with their provenance, and applies the override files
+func merge(sources []*source, index map[string]uint, include, exclude []string) (map[string]uint32, error) {
+	list := make([]datagen.Source, 0, len(sources))
+	for _, s := range sources {
+		list = append(list, datagen.Source{Name: s.Name, File: s.File, Format: s.Format, Data: s.data})
+	}
+
+	return datagen.Merge(list, index, include, exclude)
 }
 
-func getURLJson(url string, data interface{}) error {
-	resp, err := http.Get(url)
+func (s *source) fetch(snapshot string) error {
+	path := s.Path
+	if path == "" && snapshot != "" {
+		path = filepath.Join(snapshot, s.File)
+		if _, err := os.Stat(path); os.IsNotExist(err) && s.Optional {
+			return nil
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s.data = data
+		return nil
+	}
+
+	if s.URL == "" {
+		return nil
+	}
+
+	resp, err := http.Get(s.URL)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	dec := json.NewDecoder(resp.Body)
-	if err := dec.Decode(data); err != nil {
-		return err
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s failed with status %s", s.URL, resp.Status)
 	}
 
-	return nil
+	s.data, err = io.ReadAll(resp.Body)
+	return err
+}
+
+func (s *source) hash() string {
+	return fmt.Sprintf("%x", sha256.Sum256(s.data))
+}
+
+func parseRootZoneDB(body []byte) (map[string]rootZoneEntry, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	data := make(map[string]rootZoneEntry)
+	for _, m := range rootZoneRow.FindAllSubmatch(body, -1) {
+		data[strings.ToLower(string(m[1]))] = rootZoneEntry{
+			Type:    strings.TrimSpace(html.UnescapeString(string(m[2]))),
+			Sponsor: strings.TrimSpace(html.UnescapeString(string(m[3]))),
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("no tld found in the root zone database")
+	}
+
+	return data, nil
 }
 
-func getURLPHP(url string) ([]string, error) {
-	resp, err := http.Get(url)
+// parseRootZone reads the vendored root zone database, the lines are the tld, the type and the sponsor separated by
+// tab
+func parseRootZone(body []byte) (map[string]rootZoneEntry, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	data := make(map[string]rootZoneEntry)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		data[parts[0]] = rootZoneEntry{Type: parts[1], Sponsor: parts[2]}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("no tld found in the root zone database")
+	}
+
+	return data, nil
+}
+
+// rootZoneLines returns the lines of the vendored root zone database
+func rootZoneLines(db map[string]rootZoneEntry, date string) []string {
+	res := []string{
+		"# The IANA root zone database (https://www.iana.org/domains/root/db), the tld, the type and the sponsoring",
+		"# organisation separated by tab. generate.go classifies the tlds with this file when it can not fetch the database,",
+		"# and rewrites it when it does.",
+		"#",
+		"# Fetch date: " + date,
+	}
+	keys := make([]string, 0, len(db))
+	for k := range db {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		res = append(res, strings.Join([]string{k, db[k].Type, db[k].Sponsor}, "\t"))
+	}
+
+	return res
+}
+
+// readVendored reads the vendored copy of a source, the file is optional
+func readVendored(path string) (*source, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var data []string
-	reader := bufio.NewReader(resp.Body)
-	for {
-		s, err := reader.ReadString('\n')
-		if err != nil && err != io.EOF {
-			return nil, err
+	return &source{File: filepath.Base(path), Path: path, data: data}, nil
+}
+
+// buildTLDInfo classifies the tlds using the root zone database and the brand list. without the root zone database
+// the country code tlds (two letters, or an IDN in the idnCountries) and arpa are detected, the rest are generic
+func buildTLDInfo(tlds []string, db map[string]rootZoneEntry, brands []string) []tldInfo {
+	brand := make(map[string]bool, len(brands))
+	for i := range brands {
+		brand[brands[i]] = true
+	}
+
+	res := make([]tldInfo, 0, len(tlds))
+	for _, tld := range tlds {
+		info := tldInfo{Name: tld, Type: "generic"}
+		if u, err := idna.ToUnicode(tld); err == nil && u != tld {
+			info.Unicode = u
 		}
 
-		if err == io.EOF {
-			break
+		if entry, ok := db[tld]; ok {
+			info.Sponsor = entry.Sponsor
+			switch entry.Type {
+			case "country-code", "sponsored", "infrastructure", "test":
+				info.Type = entry.Type
+			}
+		} else if len(tld) == 2 || idnCountries[tld] != "" {
+			info.Type = "country-code"
+		} else if tld == "arpa" {
+			info.Type = "infrastructure"
 		}
 
-		s = strings.Trim(s, "\n\t ")
-		if len(s) < 1 {
-			continue
+		if brand[tld] && info.Type == "generic" {
+			info.Type = "brand"
 		}
 
-		if s[0] == '\'' || s[0] == '"' {
-			data = append(data, strings.Trim(s, `"',`))
+		if info.Type == "country-code" {
+			info.Country = strings.ToUpper(tld)
+			if c, ok := countryExceptions[tld]; ok {
+				info.Country = c
+			}
+			if c, ok := idnCountries[tld]; ok {
+				info.Country = c
+			}
 		}
+
+		res = append(res, info)
 	}
 
-	return data, nil
+	return res
+}
+
+// tldLines returns the lines of the tlds file, the name, type, sponsor, country and the unicode form separated by tab
+func tldLines(data []tldInfo) []string {
+	res := make([]string, 0, len(data))
+	for _, info := range data {
+		res = append(res, strings.Join([]string{info.Name, info.Type, info.Sponsor, info.Country, info.Unicode}, "\t"))
+	}
+
+	return res
+}
+
+// sourceLines returns the lines of a list file, the domain and the provenance separated by tab
+func sourceLines(data map[string]uint32) []string {
+	res := make([]string, 0, len(data))
+	for _, k := range datagen.SortedKeys(data) {
+		res = append(res, fmt.Sprintf("%s\t%d", k, data[k]))
+	}
+
+	return res
+}
+
+// maxDomainLength is the longest domain name in the DNS, the embedded data keeps the length of a domain in a byte
+const maxDomainLength = 253
+
+// checkDomains rejects the domains that can not be in the line based files
+func checkDomains(name string, data map[string]uint32) error {
+	for k := range data {
+		if strings.ContainsAny(k, "\t\n") {
+			return fmt.Errorf("%s: invalid domain %q", name, k)
+		}
+		if len(k) > maxDomainLength {
+			return fmt.Errorf("%s: the domain %q is longer than %d bytes", name, k, maxDomainLength)
+		}
+	}
+
+	return nil
 }
 
-func printMap(w io.Writer, name string, data []string) {
-	_, _ = fmt.Fprintf(w, "\nvar %s = map[string]bool {\n", name)
-	for i := range data {
-		_, _ = fmt.Fprintf(w, "\t%q: true,\n", data[i])
+func writeLines(path string, lines []string) error {
+	buf := &bytes.Buffer{}
+	for i := range lines {
+		_, _ = fmt.Fprintln(buf, lines[i])
 	}
-	fmt.Fprintln(w, "}\n")
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
 }
 
 func formatCode(src []byte, out io.Writer) error {
@@ -116,51 +444,273 @@ func formatCode(src []byte, out io.Writer) error {
 	return format.Node(out, fset, f)
 }
 
+// readPrevious returns the domains in the previously generated files, by the category name. the files are
+// optional, there is nothing to compare on the first run
+func readPrevious(dir string) (map[string]map[string]bool, error) {
+	res := make(map[string]map[string]bool)
+	for _, c := range categories {
+		data, err := os.ReadFile(filepath.Join(dir, c.File))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		keys := make(map[string]bool)
+		for _, line := range strings.Split(string(data), "\n") {
+			if k := strings.SplitN(line, "\t", 2)[0]; k != "" {
+				keys[k] = true
+			}
+		}
+		res[c.Name] = keys
+	}
+
+	return res, nil
+}
+
+func writeSnapshot(dir, date string, sources []*source) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, s := range sources {
+		if s.data == nil {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, s.File), s.data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, fetchDateFile), []byte(date+"\n"), 0644)
+}
+
 func main() {
 	f := flag.String("file", "data.go", "file to generate")
+	dataDir := flag.String("data", "data", "directory of the embedded data files, it should be in the package directory")
+	configFile := flag.String("config", "sources.json", "the sources config file")
+	overrides := flag.String("overrides", "overrides", "directory of the override files, <category>.include and <category>.exclude")
+	snapshot := flag.String("snapshot", "", "directory to read the sources from, instead of the network")
+	saveSnapshot := flag.String("save-snapshot", "", "directory to save the sources in, to use later with -snapshot")
+	date := flag.String("date", "", "the fetch date in the header, default is today for the network and the "+fetchDateFile+" file in the snapshot")
+	report := flag.String("report", "", "file to write the change report in, default is the standard output")
+	maxRemoval := flag.Float64("max-removal", 0.1, "fail if a list loses more than this ratio of its domains, 1 disables the check")
+	noRootDB := flag.Bool("no-root-db", false, "classify the tlds without the IANA root zone database")
+	rootZone := flag.String("root-zone", "rootzone.tsv", "the vendored root zone database, used when the database is not fetched and rewritten when it is")
+	brandsFile := flag.String("brands", "brands.txt", "the vendored brand tlds list, used when the list is not fetched and rewritten when it is")
+	paths := pathFlags{}
+	flag.Var(paths, "source", "read the source file from a local path, file=path (like index.json=/tmp/index.json), can be repeated")
+
+	rootDB := &source{File: "root-db.html", URL: "https://www.iana.org/domains/root/db", Optional: true}
+	brands := &source{File: "brands.txt", Optional: true}
+	flag.StringVar(&brands.URL, "brands-url", "", "url of the brand tlds list (ICANN specification 13), one tld per line")
 	flag.Parse()
 
-	fl, err := os.Create(*f)
+	cfg, err := loadConfig(*configFile)
 	if err != nil {
-		log.Print(err)
-		return
+		log.Fatal(err)
+	}
+
+	var (
+		sources []*source
+		names   []string
+		index   = make(map[string]uint)
+	)
+	for _, c := range categories {
+		for _, s := range cfg[c.Name] {
+			sources = append(sources, s)
+			if _, ok := index[s.Name]; !ok && c.Provenance {
+				index[s.Name] = uint(len(names))
+				names = append(names, s.Name)
+			}
+		}
+	}
+	index[datagen.OverrideSource] = uint(len(names))
+	names = append(names, datagen.OverrideSource)
+	if len(names) > 32 {
+		log.Fatal("there are more than 32 sources")
+	}
+
+	if *noRootDB {
+		rootDB.URL = ""
+	} else {
+		sources = append(sources, rootDB)
+	}
+	sources = append(sources, brands)
+
+	network := false
+	for _, s := range sources {
+		if p, ok := paths[s.File]; ok {
+			s.Path = p
+			delete(paths, s.File)
+		}
+		if s.Path == "" && *snapshot == "" && s.URL != "" {
+			network = true
+		}
+		if err := s.fetch(*snapshot); err != nil {
+			log.Fatalf("%s: %s", s.File, err)
+		}
+	}
+	for file := range paths {
+		log.Fatalf("there is no source with file %s", file)
+	}
+
+	if *date == "" {
+		if network {
+			*date = time.Now().UTC().Format("2006-01-02")
+		} else if *snapshot != "" {
+			if b, err := os.ReadFile(filepath.Join(*snapshot, fetchDateFile)); err == nil {
+				*date = strings.TrimSpace(string(b))
+			}
+		}
+	}
+	if *date == "" {
+		log.Fatal("the fetch date of the sources is unknown, set it with -date")
+	}
+
+	lists := make(map[string]map[string]uint32)
+	for _, c := range categories {
+		include, err := readOverride(filepath.Join(*overrides, c.Name+".include"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		exclude, err := readOverride(filepath.Join(*overrides, c.Name+".exclude"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if lists[c.Name], err = merge(cfg[c.Name], index, include, exclude); err != nil {
+			log.Fatal(err)
+		}
+		if err := checkDomains(c.Name, lists[c.Name]); err != nil {
+			log.Fatal(err)
+		}
 	}
-	defer fl.Close()
 
-	var disposable []string
-	if err := getURLJson("https://raw.githubusercontent.com/ivolo/disposable-email-domains/master/index.json", &disposable); err != nil {
-		log.Print(err)
-		return
+	// the vendored copies are added to the sources in the header, but they are not saved in the snapshot
+	fetched := sources
+	db, err := parseRootZoneDB(rootDB.data)
+	if err != nil {
+		log.Fatalf("%s: %s", rootDB.File, err)
+	}
+	fetchedDB := db != nil
+	if !fetchedDB && !*noRootDB {
+		vendored, err := readVendored(*rootZone)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if vendored != nil {
+			if db, err = parseRootZone(vendored.data); err != nil {
+				log.Fatalf("%s: %s", *rootZone, err)
+			}
+			sources = append(sources, vendored)
+			log.Printf("the root zone database is not available, tlds are classified with %s", *rootZone)
+		}
+	}
+	if db == nil {
+		log.Print("the root zone database is not available, tlds are classified without it")
 	}
 
-	var disposableWild []string
-	if err := getURLJson("https://raw.githubusercontent.com/ivolo/disposable-email-domains/master/wildcard.json", &disposableWild); err != nil {
-		log.Print(err)
-		return
+	fetchedBrands := brands.data != nil
+	if !fetchedBrands {
+		vendored, err := readVendored(*brandsFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if vendored != nil {
+			brands.data = vendored.data
+		}
 	}
 
-	freeProvider, err := getURLPHP("https://raw.githubusercontent.com/daveearley/Email-Validation-Tool/master/src/data/email-providers.php")
+	brandList, err := datagen.ParseLines(brands.data)
 	if err != nil {
-		log.Print(err)
-		return
+		log.Fatalf("%s: %s", brands.File, err)
 	}
 
-	tlds, err := getURLStrings("https://data.iana.org/TLD/tlds-alpha-by-domain.txt")
+	prev, err := readPrevious(*dataDir)
 	if err != nil {
-		log.Print(err)
-		return
+		log.Fatal(err)
+	}
+
+	rw := io.Writer(os.Stdout)
+	if *report != "" {
+		rf, err := os.Create(*report)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer rf.Close()
+		rw = rf
+	}
+
+	listNames := make([]string, 0, len(categories))
+	for _, c := range categories {
+		listNames = append(listNames, c.Name)
+	}
+	rep := datagen.NewReport(listNames, prev, lists)
+	rep.Write(rw)
+	// nothing is written before the check, a failed run does not change the data
+	if err := rep.Check(*maxRemoval); err != nil {
+		log.Fatal(err)
+	}
+
+	if *saveSnapshot != "" {
+		if err := writeSnapshot(*saveSnapshot, *date, fetched); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if fetchedDB {
+		if err := writeLines(*rootZone, rootZoneLines(db, *date)); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if fetchedBrands {
+		if err := os.WriteFile(*brandsFile, brands.data, 0644); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	buf := &bytes.Buffer{}
-	_, _ = fmt.Fprintln(buf, "// Code generated by generate.go. DO NOT EDIT.\n")
-	_, _ = fmt.Fprintln(buf, "package emailvalidator\n\n")
-	printMap(buf, "disposableDomain", disposable)
-	printMap(buf, "wildDisposableDomain", disposableWild)
-	printMap(buf, "freeProvider", freeProvider)
-	printMap(buf, "tlds", tlds)
-
-	if err := formatCode(buf.Bytes(), fl); err != nil {
-		log.Print(err)
-		return
+	_, _ = fmt.Fprintln(buf, "// Code generated by generate.go. DO NOT EDIT.")
+	_, _ = fmt.Fprintln(buf, "//")
+	_, _ = fmt.Fprintf(buf, "// Fetch date: %s\n", *date)
+	_, _ = fmt.Fprintln(buf, "// Sources (sha256):")
+	for _, s := range sources {
+		if s.data != nil {
+			_, _ = fmt.Fprintf(buf, "//   %s %s\n", s.File, s.hash())
+		}
+	}
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, "package emailvalidator")
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, `import _ "embed"`)
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, "// dataSources is the name of the sources, the provenance of a domain is the bit mask of the index of its sources")
+	_, _ = fmt.Fprintf(buf, "var dataSources = %#v\n", names)
+
+	if err := os.MkdirAll(*dataDir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	for _, c := range categories {
+		lines := sourceLines(lists[c.Name])
+		if !c.Provenance {
+			lines = tldLines(buildTLDInfo(datagen.SortedKeys(lists[c.Name]), db, brandList))
+		}
+		if err := writeLines(filepath.Join(*dataDir, c.File), lines); err != nil {
+			log.Fatal(err)
+		}
+
+		_, _ = fmt.Fprintf(buf, "\n//go:embed %s\n", filepath.ToSlash(filepath.Join(*dataDir, c.File)))
+		_, _ = fmt.Fprintf(buf, "var %sData string\n\n", c.Var)
+		_, _ = fmt.Fprintf(buf, "var %s = newDomainSet(%sData)\n", c.Var, c.Var)
+	}
+
+	out := &bytes.Buffer{}
+	if err := formatCode(buf.Bytes(), out); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*f, out.Bytes(), 0644); err != nil {
+		log.Fatal(err)
 	}
 }
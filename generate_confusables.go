@@ -0,0 +1,117 @@
+//go:build ignore
+
+// This program converts the confusables.txt of the Unicode TR39 to the data/confusables.tsv file used by the
+// homograph check:
+//
+//	go run generate_confusables.go
+//	go run generate_confusables.go -source /tmp/confusables.txt
+//
+// each line of the output is the code point of a character and the code points of its prototype, the version of the
+// source is kept in a comment line.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+const defaultSource = "https://www.unicode.org/Public/security/latest/confusables.txt"
+
+type confusable struct {
+	from string
+	to   string
+}
+
+func open(source string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		return os.Open(source)
+	}
+
+	resp, err := http.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s failed with status %d", source, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// parse reads the lines like "0430 ;	0061 ;	MA	# ( а → a ) CYRILLIC SMALL LETTER A → LATIN SMALL LETTER A #", and
+// the version from the header
+func parse(r io.Reader) (string, []confusable, error) {
+	var (
+		version string
+		res     []confusable
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		if strings.HasPrefix(line, "# Version:") {
+			version = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		parts := strings.Split(line, ";")
+		if len(parts) < 3 {
+			continue
+		}
+
+		from, to := strings.Fields(parts[0]), strings.Fields(parts[1])
+		if len(from) != 1 || len(to) == 0 {
+			return "", nil, fmt.Errorf("invalid line %q", scanner.Text())
+		}
+		res = append(res, confusable{from: from[0], to: strings.Join(to, " ")})
+	}
+
+	return version, res, scanner.Err()
+}
+
+func main() {
+	source := flag.String("source", defaultSource, "the url or the path of the confusables.txt")
+	out := flag.String("file", "data/confusables.tsv", "file to generate")
+	flag.Parse()
+
+	r, err := open(*source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	version, list, err := parse(r)
+	_ = r.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(list) == 0 {
+		log.Fatal("there is no confusable in the source")
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if len(list[i].from) != len(list[j].from) {
+			return len(list[i].from) < len(list[j].from)
+		}
+		return list[i].from < list[j].from
+	})
+
+	var b strings.Builder
+	if version != "" {
+		_, _ = fmt.Fprintf(&b, "# %s\n", version)
+	}
+	for _, c := range list {
+		_, _ = fmt.Fprintf(&b, "%s\t%s\n", c.from, c.to)
+	}
+
+	if err := os.WriteFile(*out, []byte(b.String()), 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d confusables", len(list))
+}
@@ -0,0 +1,250 @@
+//go:build ignore
+
+// This program trains the gibberish model used to detect randomly generated user names. it reads a text corpus of
+// the words used in the user names and writes the gibberish_model.go file:
+//
+//	go run generate_gibberish.go -corpus internal/gibberish/names.txt
+//
+// the default corpus is a list of the common given and family names in many languages. the character bigram table
+// is built from the words in the corpus, then a logistic regression is fitted on the features of user name like
+// combinations of the words against random strings. the features are extracted by the internal/gibberish package,
+// the same code the validator uses.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/fzerorubigd/emailvalidator/internal/gibberish"
+)
+
+// readWords reads the words of the files, the lines starting with # are comments
+func readWords(files []string) ([]string, error) {
+	var words []string
+	for _, file := range files {
+		fl, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(fl)
+		for scanner.Scan() {
+			if strings.HasPrefix(strings.TrimSpace(scanner.Text()), "#") {
+				continue
+			}
+			for _, w := range strings.Fields(scanner.Text()) {
+				w = strings.ToLower(strings.Trim(w, `.,;:!?"'()[]{}<>`))
+				if len(w) < 2 {
+					continue
+				}
+
+				ok := true
+				for _, c := range w {
+					if c < 'a' || c > 'z' {
+						ok = false
+						break
+					}
+				}
+				if ok {
+					words = append(words, w)
+				}
+			}
+		}
+		_ = fl.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return words, nil
+}
+
+func bigrams(words []string) gibberish.Bigrams {
+	var (
+		count gibberish.Bigrams
+		res   gibberish.Bigrams
+	)
+	for _, w := range words {
+		prev := gibberish.Boundary
+		for _, c := range w {
+			count[prev][c-'a']++
+			prev = int(c - 'a')
+		}
+		count[prev][gibberish.Boundary]++
+	}
+
+	for i := range count {
+		var total float64
+		for j := range count[i] {
+			total += count[i][j] + 1
+		}
+		for j := range count[i] {
+			res[i][j] = math.Log((count[i][j] + 1) / total)
+		}
+	}
+
+	return res
+}
+
+func randomString(rnd *rand.Rand) string {
+	const (
+		letters = "abcdefghijklmnopqrstuvwxyz"
+		digits  = "0123456789"
+	)
+	l := 6 + rnd.Intn(11)
+	digitRatio := rnd.Float64() * 0.5
+	b := make([]byte, l)
+	for i := range b {
+		if rnd.Float64() < digitRatio {
+			b[i] = digits[rnd.Intn(len(digits))]
+		} else {
+			b[i] = letters[rnd.Intn(len(letters))]
+		}
+	}
+
+	return string(b)
+}
+
+// nameLike returns a user name made of the words, like john.smith, jsmith, jhsmith88 or smith1984
+func nameLike(rnd *rand.Rand, words []string) string {
+	w := words[rnd.Intn(len(words))]
+	switch rnd.Intn(7) {
+	case 0:
+		return w + "." + words[rnd.Intn(len(words))]
+	case 1:
+		return w + words[rnd.Intn(len(words))]
+	case 2:
+		return w + fmt.Sprint(1950+rnd.Intn(70))
+	case 3:
+		return w[:1] + words[rnd.Intn(len(words))]
+	case 4:
+		return w[:1] + words[rnd.Intn(len(words))][:1] + words[rnd.Intn(len(words))] + fmt.Sprint(rnd.Intn(100))
+	case 5:
+		return w + fmt.Sprint(rnd.Intn(100))
+	}
+
+	return w
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
+
+func train(x [][gibberish.Features]float64, y []float64) [gibberish.Features + 1]float64 {
+	var w [gibberish.Features + 1]float64
+	const (
+		rate   = 0.5
+		epochs = 2000
+	)
+	for e := 0; e < epochs; e++ {
+		var grad [gibberish.Features + 1]float64
+		for i := range x {
+			z := w[0]
+			for j := range x[i] {
+				z += w[j+1] * x[i][j]
+			}
+			d := sigmoid(z) - y[i]
+			grad[0] += d
+			for j := range x[i] {
+				grad[j+1] += d * x[i][j]
+			}
+		}
+		for j := range w {
+			w[j] -= rate * grad[j] / float64(len(x))
+		}
+	}
+
+	return w
+}
+
+func main() {
+	corpus := flag.String("corpus", "internal/gibberish/names.txt", "comma separated list of the corpus text files")
+	out := flag.String("file", "gibberish_model.go", "file to generate")
+	samples := flag.Int("samples", 20000, "number of samples for each class")
+	flag.Parse()
+
+	if *corpus == "" {
+		log.Fatal("the corpus is required")
+	}
+
+	words, err := readWords(strings.Split(*corpus, ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(words) == 0 {
+		log.Fatal("there is no word in the corpus")
+	}
+
+	model := bigrams(words)
+
+	var long []string
+	for _, w := range words {
+		if len(w) >= 3 {
+			long = append(long, w)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	var (
+		x [][gibberish.Features]float64
+		y []float64
+	)
+	for i := 0; i < *samples; i++ {
+		x = append(x, gibberish.Extract(&model, nameLike(rnd, long)))
+		y = append(y, 0)
+		x = append(x, gibberish.Extract(&model, randomString(rnd)))
+		y = append(y, 1)
+	}
+	weights := train(x, y)
+
+	var errs int
+	for i := range x {
+		z := weights[0]
+		for j := range x[i] {
+			z += weights[j+1] * x[i][j]
+		}
+		if (sigmoid(z) >= 0.5) != (y[i] == 1) {
+			errs++
+		}
+	}
+	log.Printf("%d words, training error rate %.4f", len(words), float64(errs)/float64(len(x)))
+
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintln(buf, "// Code generated by generate_gibberish.go. DO NOT EDIT.")
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, "package emailvalidator")
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, `import "github.com/fzerorubigd/emailvalidator/internal/gibberish"`)
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, "// gibberishBigrams is the log probability of each character after another one, the last index is the word boundary")
+	_, _ = fmt.Fprintln(buf, "var gibberishBigrams = gibberish.Bigrams{")
+	for i := range model {
+		_, _ = fmt.Fprint(buf, "{")
+		for j := range model[i] {
+			_, _ = fmt.Fprintf(buf, "%.4f, ", model[i][j])
+		}
+		_, _ = fmt.Fprintln(buf, "},")
+	}
+	_, _ = fmt.Fprintln(buf, "}")
+	_, _ = fmt.Fprintln(buf)
+	_, _ = fmt.Fprintln(buf, "// gibberishWeights is the bias and the weights of the n-gram, vowel, digit, entropy and digit switch features")
+	_, _ = fmt.Fprintf(buf, "var gibberishWeights = [gibberish.Features + 1]float64{%.4f, %.4f, %.4f, %.4f, %.4f, %.4f}\n",
+		weights[0], weights[1], weights[2], weights[3], weights[4], weights[5])
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,66 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/fzerorubigd/emailvalidator/internal/gibberish"
+)
+
+const (
+	defaultGibberishThreshold = 0.9
+	defaultGibberishMinLength = 6
+)
+
+// GibberishThreshold set the probability (between 0 and 1) that a user name considered randomly generated, the
+// minimum length is the shortest user name to check, shorter user names are not checked since there is not enough
+// data in them.
+func GibberishThreshold(probability float64, minLength int) OptionSetter {
+	return func(opt *Options) error {
+		if probability <= 0 || probability > 1 {
+			return errors.New("invalid gibberish probability")
+		}
+		if minLength < 1 {
+			return errors.New("invalid gibberish minimum length")
+		}
+		opt.gibberishThreshold = probability
+		opt.gibberishMinLength = minLength
+		return nil
+	}
+}
+
+// gibberishProbability returns the probability of the user name being randomly generated
+func gibberishProbability(u string) float64 {
+	u = strings.ToLower(strings.SplitN(u, "+", 2)[0])
+	if u == "" {
+		return 0
+	}
+
+	return gibberish.Probability(&gibberishBigrams, &gibberishWeights, u)
+}
+
+func checkGibberish(_ context.Context, in *Input, res *ValidationResult) error {
+	threshold, minLength := in.opt.gibberishThreshold, in.opt.gibberishMinLength
+	if threshold == 0 {
+		threshold, minLength = defaultGibberishThreshold, defaultGibberishMinLength
+	}
+
+	u := strings.SplitN(in.UserName, "+", 2)[0]
+	if len(u) < minLength {
+		return nil
+	}
+
+	if isAllowed(res) {
+		res.Gibberish = ValidationStateFalse
+		return nil
+	}
+
+	res.GibberishScore = gibberishProbability(u)
+	res.Gibberish = ValidationStateFalse
+	if res.GibberishScore >= threshold {
+		res.Gibberish = ValidationStateTrue
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// Code generated by generate_gibberish.go. DO NOT EDIT.
+
+package emailvalidator
+
+import "github.com/fzerorubigd/emailvalidator/internal/gibberish"
+
+// gibberishBigrams is the log probability of each character after another one, the last index is the word boundary
+var gibberishBigrams = gibberish.Bigrams{
+	{-5.7466, -3.8662, -4.2061, -3.4779, -4.7249, -5.3047, -4.5765, -3.7243, -3.7849, -5.1712, -3.5871, -2.7234, -3.0093, -1.6320, -4.1371, -5.1106, -5.9979, -2.1569, -3.0165, -3.1902, -4.0726, -3.9007, -4.2802, -5.9979, -4.0318, -4.4473, -1.5999},
+	{-1.5914, -4.1866, -5.7961, -3.5988, -1.5194, -5.7961, -5.7961, -3.8501, -2.3303, -5.7961, -5.7961, -3.4935, -5.7961, -5.7961, -2.0349, -5.7961, -5.7961, -2.1325, -5.1029, -5.7961, -3.0880, -5.1029, -5.7961, -5.7961, -4.4098, -5.7961, -3.5988},
+	{-2.0044, -6.1633, -4.7770, -5.4702, -2.4021, -6.1633, -6.1633, -1.1460, -2.4744, -5.4702, -3.2729, -3.8607, -6.1633, -6.1633, -2.2513, -5.4702, -5.0647, -3.9661, -4.7770, -5.0647, -4.3716, -6.1633, -6.1633, -6.1633, -5.0647, -3.5243, -2.4998},
+	{-1.7918, -5.6733, -6.3665, -4.2870, -1.7714, -6.3665, -5.6733, -4.2870, -2.0760, -5.2679, -6.3665, -4.5747, -4.4206, -5.2679, -2.1768, -6.3665, -6.3665, -2.3591, -4.2870, -4.9802, -2.9653, -5.2679, -4.5747, -6.3665, -4.2870, -5.2679, -1.9238},
+	{-4.0806, -4.1939, -4.6402, -3.5209, -3.6751, -4.5796, -4.4170, -4.2347, -3.3874, -5.2155, -3.7752, -2.4223, -3.3874, -2.0562, -4.0116, -4.5224, -7.4128, -1.7851, -2.6336, -3.2231, -4.5224, -3.6061, -4.1547, -5.1102, -4.3217, -3.4809, -1.9574},
+	{-1.6390, -5.3279, -5.3279, -5.3279, -1.8621, -3.2484, -4.6347, -5.3279, -2.1498, -5.3279, -5.3279, -3.2484, -3.9416, -5.3279, -2.7629, -5.3279, -5.3279, -2.0320, -4.6347, -5.3279, -2.8430, -5.3279, -5.3279, -5.3279, -5.3279, -5.3279, -2.3834},
+	{-1.9504, -5.4161, -5.4161, -4.3175, -2.1972, -6.1092, -5.4161, -2.9738, -2.6435, -6.1092, -6.1092, -4.3175, -5.0106, -3.8067, -2.4457, -6.1092, -6.1092, -3.0182, -6.1092, -5.0106, -2.2591, -6.1092, -5.4161, -6.1092, -4.4998, -6.1092, -1.2971},
+	{-1.2958, -6.6333, -5.9402, -5.0239, -1.9328, -6.6333, -6.6333, -6.6333, -1.9794, -6.6333, -6.6333, -4.6874, -3.3011, -4.2354, -2.4589, -5.9402, -6.6333, -3.4978, -5.0239, -4.3307, -2.5558, -6.6333, -5.0239, -6.6333, -4.0684, -6.6333, -2.1115},
+	{-2.2930, -4.8520, -2.8118, -3.4852, -2.7348, -4.9321, -4.1981, -5.2198, -6.3184, -5.0191, -3.4467, -2.7823, -3.2123, -1.9531, -3.5458, -4.5266, -5.3375, -3.2898, -2.5887, -3.0732, -4.4725, -4.7779, -5.2198, -5.8075, -4.9321, -4.2389, -1.7680},
+	{-1.3752, -5.5947, -4.2084, -5.5947, -2.1607, -5.5947, -5.5947, -5.5947, -1.7880, -5.5947, -4.4961, -5.5947, -5.5947, -4.9016, -1.6435, -5.5947, -5.5947, -5.5947, -4.9016, -5.5947, -1.9571, -5.5947, -5.5947, -5.5947, -4.9016, -5.5947, -3.8030},
+	{-1.5049, -5.6870, -6.3801, -5.6870, -2.6425, -6.3801, -6.3801, -3.6721, -1.8915, -6.3801, -4.3007, -4.4342, -5.6870, -5.2815, -1.7262, -6.3801, -6.3801, -3.3356, -3.5469, -4.4342, -3.0843, -6.3801, -3.6075, -6.3801, -5.2815, -6.3801, -1.9493},
+	{-1.6909, -5.7398, -5.0466, -3.7474, -1.7508, -5.4521, -4.8925, -5.0466, -1.9786, -6.8384, -4.8925, -2.6953, -4.2735, -5.2290, -2.3958, -5.4521, -6.1453, -5.7398, -3.6604, -4.7590, -3.1495, -3.9480, -5.7398, -6.8384, -3.7029, -5.0466, -2.0848},
+	{-0.9804, -3.9627, -5.2845, -5.2845, -2.1821, -6.6708, -6.6708, -6.6708, -1.7010, -6.6708, -5.9776, -6.6708, -4.0317, -6.6708, -2.3533, -4.4735, -6.6708, -4.8790, -4.5913, -5.9776, -3.0598, -5.9776, -5.9776, -6.6708, -4.5913, -5.9776, -2.5436},
+	{-1.9550, -6.6464, -3.9056, -2.6574, -2.6300, -6.2409, -2.3091, -4.7746, -2.6121, -4.7005, -3.9383, -5.9532, -6.2409, -3.3883, -3.0491, -6.6464, -7.3395, -5.1423, -3.4077, -3.0491, -4.5669, -7.3395, -5.9532, -7.3395, -4.4492, -4.9416, -1.2874},
+	{-4.1641, -3.9897, -4.5695, -4.3182, -5.2627, -4.5006, -4.1641, -3.7121, -4.7237, -4.8107, -4.0305, -2.6977, -3.2013, -1.9511, -3.8764, -4.0731, -6.1100, -2.3723, -2.6339, -3.6822, -3.2196, -2.7427, -3.6251, -5.8223, -4.4360, -4.0305, -1.5738},
+	{-1.5041, -4.9053, -4.9053, -5.5984, -1.6281, -5.5984, -5.5984, -2.4629, -2.6540, -5.5984, -5.5984, -4.9053, -5.5984, -4.9053, -1.9608, -3.0335, -5.5984, -2.7652, -4.2121, -4.4998, -4.4998, -5.5984, -5.5984, -5.5984, -4.4998, -5.5984, -2.8904},
+	{-2.7246, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -1.7130, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -1.1151, -4.1109, -4.1109, -4.1109, -4.1109, -4.1109, -3.0123},
+	{-1.7322, -4.9185, -4.1766, -3.6102, -2.3158, -6.5280, -3.7246, -5.4293, -1.7446, -5.6117, -4.0856, -4.3307, -4.5820, -3.7871, -2.3085, -5.8348, -6.1225, -3.6376, -3.6658, -3.1781, -3.5835, -5.2752, -5.8348, -7.2211, -3.6658, -4.4485, -2.0281},
+	{-2.0664, -7.0432, -3.7110, -7.0432, -2.4084, -5.9445, -7.0432, -2.3247, -2.8237, -7.0432, -3.1513, -3.8243, -4.2706, -5.4337, -2.5106, -5.2514, -6.3500, -5.0972, -2.9488, -2.5106, -3.2145, -4.9637, -5.9445, -7.0432, -5.4337, -3.8651, -1.5667},
+	{-1.7001, -6.6201, -5.9269, -6.6201, -2.1203, -6.6201, -6.6201, -2.3434, -1.9016, -6.6201, -4.8283, -5.2338, -5.5215, -5.9269, -2.0662, -6.6201, -6.6201, -2.6882, -3.9120, -3.1236, -3.9120, -5.5215, -6.6201, -6.6201, -4.5406, -4.8283, -2.1542},
+	{-3.2174, -3.9483, -3.2551, -3.2551, -2.8497, -4.9038, -3.9483, -4.4338, -3.2174, -4.7215, -2.9869, -2.6012, -3.2944, -2.1825, -4.0283, -4.7215, -6.5132, -2.3236, -2.2228, -3.2944, -5.8201, -5.8201, -4.9038, -5.1269, -4.2106, -4.0283, -2.1188},
+	{-1.5099, -5.8406, -4.2312, -5.8406, -2.1271, -5.8406, -5.1475, -5.1475, -1.5780, -5.8406, -5.8406, -3.6434, -5.8406, -5.8406, -3.2016, -5.8406, -5.8406, -3.8947, -5.8406, -5.8406, -4.0489, -5.1475, -5.8406, -5.8406, -3.6434, -5.8406, -1.3863},
+	{-1.1924, -5.5491, -4.4505, -4.4505, -2.2532, -4.8559, -5.5491, -4.4505, -2.1818, -5.5491, -4.8559, -4.1628, -5.5491, -4.8559, -2.1818, -5.5491, -5.5491, -3.7573, -2.1479, -5.5491, -3.4696, -5.5491, -5.5491, -5.5491, -4.8559, -5.5491, -2.6587},
+	{-2.3716, -4.3175, -4.3175, -4.3175, -2.7081, -4.3175, -4.3175, -4.3175, -1.0217, -4.3175, -4.3175, -4.3175, -4.3175, -4.3175, -3.6243, -4.3175, -4.3175, -4.3175, -4.3175, -4.3175, -2.5257, -4.3175, -4.3175, -4.3175, -4.3175, -4.3175, -2.3716},
+	{-1.5793, -5.8833, -5.1902, -4.0916, -2.2457, -5.8833, -5.8833, -5.8833, -3.1107, -5.8833, -3.4854, -3.3184, -3.9374, -3.0501, -2.5160, -5.8833, -5.8833, -4.7847, -3.6861, -5.1902, -2.2998, -5.1902, -5.8833, -5.8833, -5.8833, -5.8833, -1.4645},
+	{-1.7952, -4.5678, -4.5678, -4.2801, -2.4083, -5.6664, -5.6664, -2.9584, -2.5754, -5.6664, -3.8747, -3.8747, -3.8747, -4.5678, -3.1015, -5.6664, -4.9733, -4.9733, -3.8747, -4.2801, -3.3638, -5.6664, -4.9733, -5.6664, -3.1015, -4.2801, -1.2238},
+	{-2.5106, -3.1853, -3.0814, -3.2611, -3.7746, -3.4419, -3.2611, -3.0619, -3.9782, -2.9405, -2.8636, -3.0880, -2.3401, -3.3346, -3.8286, -3.2150, -5.5404, -3.0054, -2.3785, -3.2931, -5.2149, -3.7233, -3.6745, -4.9273, -3.6865, -4.2552, -8.1053},
+}
+
+// gibberishWeights is the bias and the weights of the n-gram, vowel, digit, entropy and digit switch features
+var gibberishWeights = [gibberish.Features + 1]float64{-7.7859, 4.0969, -4.4255, 1.6565, -5.8492, 9.5255}
@@ -0,0 +1,44 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGibberish(t *testing.T) {
+	for _, u := range []string{"xk2q9vz8pl", "qwrtzpkdl", "a8f9g7h6j5", "k9z3m1x8"} {
+		res, err := Validate(u + "@yahoo.com")
+		require.NoError(t, err)
+		assert.Equal(t, ValidationStateTrue, res.Gibberish, u)
+		assert.True(t, res.GibberishScore >= defaultGibberishThreshold, u)
+	}
+
+	for _, u := range []string{"john.smith", "alexander", "nguyen.van.an", "user12345", "test.with.dot+xk2q9vz8pl",
+		// the names in other languages
+		"krzysztof", "xiaoqiang", "mkowalczyk", "hvwang88", "przemyslaw", "szczepanski", "oluwaseun", "zhangwei88",
+		"tkachenko", "ngoc.anh"} {
+		res, err := Validate(u + "@yahoo.com")
+		require.NoError(t, err)
+		assert.Equal(t, ValidationStateFalse, res.Gibberish, u)
+	}
+
+	res, err := Validate("xkq9z@yahoo.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateNotChecked, res.Gibberish)
+	assert.Equal(t, float64(0), res.GibberishScore)
+
+	res, err = Validate("xkq9z@yahoo.com", GibberishThreshold(0.5, 3))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.Gibberish)
+
+	res, err = Validate("xk2q9vz8pl@yahoo.com", GibberishThreshold(1, 3))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.Gibberish)
+
+	_, err = Validate("john@yahoo.com", GibberishThreshold(0, 3))
+	require.Error(t, err)
+	_, err = Validate("john@yahoo.com", GibberishThreshold(0.5, 0))
+	require.Error(t, err)
+}
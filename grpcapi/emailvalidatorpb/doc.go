@@ -0,0 +1,4 @@
+// Package emailvalidatorpb is the protobuf and gRPC code generated from validator.proto.
+package emailvalidatorpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative validator.proto
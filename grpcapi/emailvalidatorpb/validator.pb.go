@@ -0,0 +1,1036 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: validator.proto
+
+package emailvalidatorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ValidationState is the state of a check, the NOT_CHECKED is the null in the json form.
+type ValidationState int32
+
+const (
+	ValidationState_VALIDATION_STATE_NOT_CHECKED ValidationState = 0
+	ValidationState_VALIDATION_STATE_TRUE        ValidationState = 1
+	ValidationState_VALIDATION_STATE_FALSE       ValidationState = 2
+)
+
+// Enum value maps for ValidationState.
+var (
+	ValidationState_name = map[int32]string{
+		0: "VALIDATION_STATE_NOT_CHECKED",
+		1: "VALIDATION_STATE_TRUE",
+		2: "VALIDATION_STATE_FALSE",
+	}
+	ValidationState_value = map[string]int32{
+		"VALIDATION_STATE_NOT_CHECKED": 0,
+		"VALIDATION_STATE_TRUE":        1,
+		"VALIDATION_STATE_FALSE":       2,
+	}
+)
+
+func (x ValidationState) Enum() *ValidationState {
+	p := new(ValidationState)
+	*p = x
+	return p
+}
+
+func (x ValidationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValidationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_validator_proto_enumTypes[0].Descriptor()
+}
+
+func (ValidationState) Type() protoreflect.EnumType {
+	return &file_validator_proto_enumTypes[0]
+}
+
+func (x ValidationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValidationState.Descriptor instead.
+func (ValidationState) EnumDescriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{0}
+}
+
+// ListMatch is the entry in the allow or deny list that matched the address.
+type ListMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	List          string                 `protobuf:"bytes,1,opt,name=list,proto3" json:"list,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatch) Reset() {
+	*x = ListMatch{}
+	mi := &file_validator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatch) ProtoMessage() {}
+
+func (x *ListMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatch.ProtoReflect.Descriptor instead.
+func (*ListMatch) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListMatch) GetList() string {
+	if x != nil {
+		return x.List
+	}
+	return ""
+}
+
+func (x *ListMatch) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// TLDInfo is the metadata of a top level domain.
+type TLDInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Sponsor       string                 `protobuf:"bytes,3,opt,name=sponsor,proto3" json:"sponsor,omitempty"`
+	Country       string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Unicode       string                 `protobuf:"bytes,5,opt,name=unicode,proto3" json:"unicode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TLDInfo) Reset() {
+	*x = TLDInfo{}
+	mi := &file_validator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TLDInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLDInfo) ProtoMessage() {}
+
+func (x *TLDInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLDInfo.ProtoReflect.Descriptor instead.
+func (*TLDInfo) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TLDInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TLDInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TLDInfo) GetSponsor() string {
+	if x != nil {
+		return x.Sponsor
+	}
+	return ""
+}
+
+func (x *TLDInfo) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *TLDInfo) GetUnicode() string {
+	if x != nil {
+		return x.Unicode
+	}
+	return ""
+}
+
+// ValidationResult mirrors the emailvalidator.ValidationResult.
+type ValidationResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	FreeProvider      ValidationState        `protobuf:"varint,1,opt,name=free_provider,json=freeProvider,proto3,enum=emailvalidator.v1.ValidationState" json:"free_provider,omitempty"`
+	Disposable        ValidationState        `protobuf:"varint,2,opt,name=disposable,proto3,enum=emailvalidator.v1.ValidationState" json:"disposable,omitempty"`
+	MxValidation      ValidationState        `protobuf:"varint,3,opt,name=mx_validation,json=mxValidation,proto3,enum=emailvalidator.v1.ValidationState" json:"mx_validation,omitempty"`
+	BlackList         ValidationState        `protobuf:"varint,4,opt,name=black_list,json=blackList,proto3,enum=emailvalidator.v1.ValidationState" json:"black_list,omitempty"`
+	BlackListCategory string                 `protobuf:"bytes,5,opt,name=black_list_category,json=blackListCategory,proto3" json:"black_list_category,omitempty"`
+	Gibberish         ValidationState        `protobuf:"varint,6,opt,name=gibberish,proto3,enum=emailvalidator.v1.ValidationState" json:"gibberish,omitempty"`
+	GibberishScore    float64                `protobuf:"fixed64,7,opt,name=gibberish_score,json=gibberishScore,proto3" json:"gibberish_score,omitempty"`
+	MixedScript       ValidationState        `protobuf:"varint,8,opt,name=mixed_script,json=mixedScript,proto3,enum=emailvalidator.v1.ValidationState" json:"mixed_script,omitempty"`
+	Homograph         ValidationState        `protobuf:"varint,9,opt,name=homograph,proto3,enum=emailvalidator.v1.ValidationState" json:"homograph,omitempty"`
+	ImitatedDomain    string                 `protobuf:"bytes,10,opt,name=imitated_domain,json=imitatedDomain,proto3" json:"imitated_domain,omitempty"`
+	Denied            ValidationState        `protobuf:"varint,11,opt,name=denied,proto3,enum=emailvalidator.v1.ValidationState" json:"denied,omitempty"`
+	ListMatch         *ListMatch             `protobuf:"bytes,12,opt,name=list_match,json=listMatch,proto3" json:"list_match,omitempty"`
+	SpecialUse        ValidationState        `protobuf:"varint,13,opt,name=special_use,json=specialUse,proto3,enum=emailvalidator.v1.ValidationState" json:"special_use,omitempty"`
+	SpecialUseKind    string                 `protobuf:"bytes,14,opt,name=special_use_kind,json=specialUseKind,proto3" json:"special_use_kind,omitempty"`
+	Tld               *TLDInfo               `protobuf:"bytes,15,opt,name=tld,proto3" json:"tld,omitempty"`
+	// mx_status is the outcome of the MX check, like nxdomain or timeout, empty if it is not performed.
+	MxStatus      string          `protobuf:"bytes,16,opt,name=mx_status,json=mxStatus,proto3" json:"mx_status,omitempty"`
+	Typo          ValidationState `protobuf:"varint,17,opt,name=typo,proto3,enum=emailvalidator.v1.ValidationState" json:"typo,omitempty"`
+	Suggestion    string          `protobuf:"bytes,18,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationResult) Reset() {
+	*x = ValidationResult{}
+	mi := &file_validator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResult) ProtoMessage() {}
+
+func (x *ValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResult.ProtoReflect.Descriptor instead.
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidationResult) GetFreeProvider() ValidationState {
+	if x != nil {
+		return x.FreeProvider
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetDisposable() ValidationState {
+	if x != nil {
+		return x.Disposable
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetMxValidation() ValidationState {
+	if x != nil {
+		return x.MxValidation
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetBlackList() ValidationState {
+	if x != nil {
+		return x.BlackList
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetBlackListCategory() string {
+	if x != nil {
+		return x.BlackListCategory
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetGibberish() ValidationState {
+	if x != nil {
+		return x.Gibberish
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetGibberishScore() float64 {
+	if x != nil {
+		return x.GibberishScore
+	}
+	return 0
+}
+
+func (x *ValidationResult) GetMixedScript() ValidationState {
+	if x != nil {
+		return x.MixedScript
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetHomograph() ValidationState {
+	if x != nil {
+		return x.Homograph
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetImitatedDomain() string {
+	if x != nil {
+		return x.ImitatedDomain
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetDenied() ValidationState {
+	if x != nil {
+		return x.Denied
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetListMatch() *ListMatch {
+	if x != nil {
+		return x.ListMatch
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetSpecialUse() ValidationState {
+	if x != nil {
+		return x.SpecialUse
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetSpecialUseKind() string {
+	if x != nil {
+		return x.SpecialUseKind
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetTld() *TLDInfo {
+	if x != nil {
+		return x.Tld
+	}
+	return nil
+}
+
+func (x *ValidationResult) GetMxStatus() string {
+	if x != nil {
+		return x.MxStatus
+	}
+	return ""
+}
+
+func (x *ValidationResult) GetTypo() ValidationState {
+	if x != nil {
+		return x.Typo
+	}
+	return ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+func (x *ValidationResult) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+// AccessList mirrors the emailvalidator.AccessList.
+type AccessList struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Addresses        []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Domains          []string               `protobuf:"bytes,2,rep,name=domains,proto3" json:"domains,omitempty"`
+	DomainSuffixes   []string               `protobuf:"bytes,3,rep,name=domain_suffixes,json=domainSuffixes,proto3" json:"domain_suffixes,omitempty"`
+	UsernamePatterns []string               `protobuf:"bytes,4,rep,name=username_patterns,json=usernamePatterns,proto3" json:"username_patterns,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AccessList) Reset() {
+	*x = AccessList{}
+	mi := &file_validator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessList) ProtoMessage() {}
+
+func (x *AccessList) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessList.ProtoReflect.Descriptor instead.
+func (*AccessList) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AccessList) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *AccessList) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *AccessList) GetDomainSuffixes() []string {
+	if x != nil {
+		return x.DomainSuffixes
+	}
+	return nil
+}
+
+func (x *AccessList) GetUsernamePatterns() []string {
+	if x != nil {
+		return x.UsernamePatterns
+	}
+	return nil
+}
+
+// MXCheck is the CheckMX option.
+type MXCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MXCheck) Reset() {
+	*x = MXCheck{}
+	mi := &file_validator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MXCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MXCheck) ProtoMessage() {}
+
+func (x *MXCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MXCheck.ProtoReflect.Descriptor instead.
+func (*MXCheck) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MXCheck) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *MXCheck) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// GibberishThreshold is the GibberishThreshold option.
+type GibberishThreshold struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Probability   float64                `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
+	MinLength     int32                  `protobuf:"varint,2,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GibberishThreshold) Reset() {
+	*x = GibberishThreshold{}
+	mi := &file_validator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GibberishThreshold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GibberishThreshold) ProtoMessage() {}
+
+func (x *GibberishThreshold) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GibberishThreshold.ProtoReflect.Descriptor instead.
+func (*GibberishThreshold) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GibberishThreshold) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *GibberishThreshold) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+// Options is the set of the options of a validation, each field is the option with the same name in the
+// emailvalidator package. the unset fields are not applied.
+type Options struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CheckMx            *MXCheck               `protobuf:"bytes,1,opt,name=check_mx,json=checkMx,proto3" json:"check_mx,omitempty"`
+	GibberishThreshold *GibberishThreshold    `protobuf:"bytes,2,opt,name=gibberish_threshold,json=gibberishThreshold,proto3" json:"gibberish_threshold,omitempty"`
+	ProtectedDomains   []string               `protobuf:"bytes,3,rep,name=protected_domains,json=protectedDomains,proto3" json:"protected_domains,omitempty"`
+	AllowList          *AccessList            `protobuf:"bytes,4,opt,name=allow_list,json=allowList,proto3" json:"allow_list,omitempty"`
+	DenyList           *AccessList            `protobuf:"bytes,5,opt,name=deny_list,json=denyList,proto3" json:"deny_list,omitempty"`
+	RejectSpecialUse   []string               `protobuf:"bytes,6,rep,name=reject_special_use,json=rejectSpecialUse,proto3" json:"reject_special_use,omitempty"`
+	AllowSpecialUse    []string               `protobuf:"bytes,7,rep,name=allow_special_use,json=allowSpecialUse,proto3" json:"allow_special_use,omitempty"`
+	RejectDenied       bool                   `protobuf:"varint,8,opt,name=reject_denied,json=rejectDenied,proto3" json:"reject_denied,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	mi := &file_validator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Options) GetCheckMx() *MXCheck {
+	if x != nil {
+		return x.CheckMx
+	}
+	return nil
+}
+
+func (x *Options) GetGibberishThreshold() *GibberishThreshold {
+	if x != nil {
+		return x.GibberishThreshold
+	}
+	return nil
+}
+
+func (x *Options) GetProtectedDomains() []string {
+	if x != nil {
+		return x.ProtectedDomains
+	}
+	return nil
+}
+
+func (x *Options) GetAllowList() *AccessList {
+	if x != nil {
+		return x.AllowList
+	}
+	return nil
+}
+
+func (x *Options) GetDenyList() *AccessList {
+	if x != nil {
+		return x.DenyList
+	}
+	return nil
+}
+
+func (x *Options) GetRejectSpecialUse() []string {
+	if x != nil {
+		return x.RejectSpecialUse
+	}
+	return nil
+}
+
+func (x *Options) GetAllowSpecialUse() []string {
+	if x != nil {
+		return x.AllowSpecialUse
+	}
+	return nil
+}
+
+func (x *Options) GetRejectDenied() bool {
+	if x != nil {
+		return x.RejectDenied
+	}
+	return false
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Options       *Options               `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_validator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ValidateRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ValidateRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ValidateBatchRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Addresses []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Options   *Options               `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	// concurrency is the number of the addresses validated at the same time, the default is used if it is zero.
+	Concurrency   int32 `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBatchRequest) Reset() {
+	*x = ValidateBatchRequest{}
+	mi := &file_validator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBatchRequest) ProtoMessage() {}
+
+func (x *ValidateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBatchRequest.ProtoReflect.Descriptor instead.
+func (*ValidateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidateBatchRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ValidateBatchRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ValidateBatchRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+// ValidateResponse is the result of one address. an invalid address is not an error of the call, the valid is false
+// and the error is the reason.
+type ValidateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is the position of the address in the batch or the stream.
+	Index         int64             `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Address       string            `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Valid         bool              `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
+	Error         string            `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Result        *ValidationResult `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_validator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_validator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateResponse) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ValidateResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidateResponse) GetResult() *ValidationResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_validator_proto protoreflect.FileDescriptor
+
+const file_validator_proto_rawDesc = "" +
+	"\n" +
+	"\x0fvalidator.proto\x12\x11emailvalidator.v1\x1a\x1egoogle/protobuf/duration.proto\"I\n" +
+	"\tListMatch\x12\x12\n" +
+	"\x04list\x18\x01 \x01(\tR\x04list\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x7f\n" +
+	"\aTLDInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\asponsor\x18\x03 \x01(\tR\asponsor\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x18\n" +
+	"\aunicode\x18\x05 \x01(\tR\aunicode\"\x83\b\n" +
+	"\x10ValidationResult\x12G\n" +
+	"\rfree_provider\x18\x01 \x01(\x0e2\".emailvalidator.v1.ValidationStateR\ffreeProvider\x12B\n" +
+	"\n" +
+	"disposable\x18\x02 \x01(\x0e2\".emailvalidator.v1.ValidationStateR\n" +
+	"disposable\x12G\n" +
+	"\rmx_validation\x18\x03 \x01(\x0e2\".emailvalidator.v1.ValidationStateR\fmxValidation\x12A\n" +
+	"\n" +
+	"black_list\x18\x04 \x01(\x0e2\".emailvalidator.v1.ValidationStateR\tblackList\x12.\n" +
+	"\x13black_list_category\x18\x05 \x01(\tR\x11blackListCategory\x12@\n" +
+	"\tgibberish\x18\x06 \x01(\x0e2\".emailvalidator.v1.ValidationStateR\tgibberish\x12'\n" +
+	"\x0fgibberish_score\x18\a \x01(\x01R\x0egibberishScore\x12E\n" +
+	"\fmixed_script\x18\b \x01(\x0e2\".emailvalidator.v1.ValidationStateR\vmixedScript\x12@\n" +
+	"\thomograph\x18\t \x01(\x0e2\".emailvalidator.v1.ValidationStateR\thomograph\x12'\n" +
+	"\x0fimitated_domain\x18\n" +
+	" \x01(\tR\x0eimitatedDomain\x12:\n" +
+	"\x06denied\x18\v \x01(\x0e2\".emailvalidator.v1.ValidationStateR\x06denied\x12;\n" +
+	"\n" +
+	"list_match\x18\f \x01(\v2\x1c.emailvalidator.v1.ListMatchR\tlistMatch\x12C\n" +
+	"\vspecial_use\x18\r \x01(\x0e2\".emailvalidator.v1.ValidationStateR\n" +
+	"specialUse\x12(\n" +
+	"\x10special_use_kind\x18\x0e \x01(\tR\x0especialUseKind\x12,\n" +
+	"\x03tld\x18\x0f \x01(\v2\x1a.emailvalidator.v1.TLDInfoR\x03tld\x12\x1b\n" +
+	"\tmx_status\x18\x10 \x01(\tR\bmxStatus\x126\n" +
+	"\x04typo\x18\x11 \x01(\x0e2\".emailvalidator.v1.ValidationStateR\x04typo\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x12 \x01(\tR\n" +
+	"suggestion\"\x9a\x01\n" +
+	"\n" +
+	"AccessList\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\x12\x18\n" +
+	"\adomains\x18\x02 \x03(\tR\adomains\x12'\n" +
+	"\x0fdomain_suffixes\x18\x03 \x03(\tR\x0edomainSuffixes\x12+\n" +
+	"\x11username_patterns\x18\x04 \x03(\tR\x10usernamePatterns\"T\n" +
+	"\aMXCheck\x123\n" +
+	"\atimeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"U\n" +
+	"\x12GibberishThreshold\x12 \n" +
+	"\vprobability\x18\x01 \x01(\x01R\vprobability\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\x02 \x01(\x05R\tminLength\"\xbe\x03\n" +
+	"\aOptions\x125\n" +
+	"\bcheck_mx\x18\x01 \x01(\v2\x1a.emailvalidator.v1.MXCheckR\acheckMx\x12V\n" +
+	"\x13gibberish_threshold\x18\x02 \x01(\v2%.emailvalidator.v1.GibberishThresholdR\x12gibberishThreshold\x12+\n" +
+	"\x11protected_domains\x18\x03 \x03(\tR\x10protectedDomains\x12<\n" +
+	"\n" +
+	"allow_list\x18\x04 \x01(\v2\x1d.emailvalidator.v1.AccessListR\tallowList\x12:\n" +
+	"\tdeny_list\x18\x05 \x01(\v2\x1d.emailvalidator.v1.AccessListR\bdenyList\x12,\n" +
+	"\x12reject_special_use\x18\x06 \x03(\tR\x10rejectSpecialUse\x12*\n" +
+	"\x11allow_special_use\x18\a \x03(\tR\x0fallowSpecialUse\x12#\n" +
+	"\rreject_denied\x18\b \x01(\bR\frejectDenied\"a\n" +
+	"\x0fValidateRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x124\n" +
+	"\aoptions\x18\x02 \x01(\v2\x1a.emailvalidator.v1.OptionsR\aoptions\"\x8c\x01\n" +
+	"\x14ValidateBatchRequest\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\x124\n" +
+	"\aoptions\x18\x02 \x01(\v2\x1a.emailvalidator.v1.OptionsR\aoptions\x12 \n" +
+	"\vconcurrency\x18\x03 \x01(\x05R\vconcurrency\"\xab\x01\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05valid\x18\x03 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12;\n" +
+	"\x06result\x18\x05 \x01(\v2#.emailvalidator.v1.ValidationResultR\x06result*j\n" +
+	"\x0fValidationState\x12 \n" +
+	"\x1cVALIDATION_STATE_NOT_CHECKED\x10\x00\x12\x19\n" +
+	"\x15VALIDATION_STATE_TRUE\x10\x01\x12\x1a\n" +
+	"\x16VALIDATION_STATE_FALSE\x10\x022\xa7\x02\n" +
+	"\x10ValidatorService\x12S\n" +
+	"\bValidate\x12\".emailvalidator.v1.ValidateRequest\x1a#.emailvalidator.v1.ValidateResponse\x12_\n" +
+	"\rValidateBatch\x12'.emailvalidator.v1.ValidateBatchRequest\x1a#.emailvalidator.v1.ValidateResponse0\x01\x12]\n" +
+	"\x0eValidateStream\x12\".emailvalidator.v1.ValidateRequest\x1a#.emailvalidator.v1.ValidateResponse(\x010\x01B@Z>github.com/fzerorubigd/emailvalidator/grpcapi/emailvalidatorpbb\x06proto3"
+
+var (
+	file_validator_proto_rawDescOnce sync.Once
+	file_validator_proto_rawDescData []byte
+)
+
+func file_validator_proto_rawDescGZIP() []byte {
+	file_validator_proto_rawDescOnce.Do(func() {
+		file_validator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_validator_proto_rawDesc), len(file_validator_proto_rawDesc)))
+	})
+	return file_validator_proto_rawDescData
+}
+
+var file_validator_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_validator_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_validator_proto_goTypes = []any{
+	(ValidationState)(0),         // 0: emailvalidator.v1.ValidationState
+	(*ListMatch)(nil),            // 1: emailvalidator.v1.ListMatch
+	(*TLDInfo)(nil),              // 2: emailvalidator.v1.TLDInfo
+	(*ValidationResult)(nil),     // 3: emailvalidator.v1.ValidationResult
+	(*AccessList)(nil),           // 4: emailvalidator.v1.AccessList
+	(*MXCheck)(nil),              // 5: emailvalidator.v1.MXCheck
+	(*GibberishThreshold)(nil),   // 6: emailvalidator.v1.GibberishThreshold
+	(*Options)(nil),              // 7: emailvalidator.v1.Options
+	(*ValidateRequest)(nil),      // 8: emailvalidator.v1.ValidateRequest
+	(*ValidateBatchRequest)(nil), // 9: emailvalidator.v1.ValidateBatchRequest
+	(*ValidateResponse)(nil),     // 10: emailvalidator.v1.ValidateResponse
+	(*durationpb.Duration)(nil),  // 11: google.protobuf.Duration
+}
+var file_validator_proto_depIdxs = []int32{
+	0,  // 0: emailvalidator.v1.ValidationResult.free_provider:type_name -> emailvalidator.v1.ValidationState
+	0,  // 1: emailvalidator.v1.ValidationResult.disposable:type_name -> emailvalidator.v1.ValidationState
+	0,  // 2: emailvalidator.v1.ValidationResult.mx_validation:type_name -> emailvalidator.v1.ValidationState
+	0,  // 3: emailvalidator.v1.ValidationResult.black_list:type_name -> emailvalidator.v1.ValidationState
+	0,  // 4: emailvalidator.v1.ValidationResult.gibberish:type_name -> emailvalidator.v1.ValidationState
+	0,  // 5: emailvalidator.v1.ValidationResult.mixed_script:type_name -> emailvalidator.v1.ValidationState
+	0,  // 6: emailvalidator.v1.ValidationResult.homograph:type_name -> emailvalidator.v1.ValidationState
+	0,  // 7: emailvalidator.v1.ValidationResult.denied:type_name -> emailvalidator.v1.ValidationState
+	1,  // 8: emailvalidator.v1.ValidationResult.list_match:type_name -> emailvalidator.v1.ListMatch
+	0,  // 9: emailvalidator.v1.ValidationResult.special_use:type_name -> emailvalidator.v1.ValidationState
+	2,  // 10: emailvalidator.v1.ValidationResult.tld:type_name -> emailvalidator.v1.TLDInfo
+	0,  // 11: emailvalidator.v1.ValidationResult.typo:type_name -> emailvalidator.v1.ValidationState
+	11, // 12: emailvalidator.v1.MXCheck.timeout:type_name -> google.protobuf.Duration
+	5,  // 13: emailvalidator.v1.Options.check_mx:type_name -> emailvalidator.v1.MXCheck
+	6,  // 14: emailvalidator.v1.Options.gibberish_threshold:type_name -> emailvalidator.v1.GibberishThreshold
+	4,  // 15: emailvalidator.v1.Options.allow_list:type_name -> emailvalidator.v1.AccessList
+	4,  // 16: emailvalidator.v1.Options.deny_list:type_name -> emailvalidator.v1.AccessList
+	7,  // 17: emailvalidator.v1.ValidateRequest.options:type_name -> emailvalidator.v1.Options
+	7,  // 18: emailvalidator.v1.ValidateBatchRequest.options:type_name -> emailvalidator.v1.Options
+	3,  // 19: emailvalidator.v1.ValidateResponse.result:type_name -> emailvalidator.v1.ValidationResult
+	8,  // 20: emailvalidator.v1.ValidatorService.Validate:input_type -> emailvalidator.v1.ValidateRequest
+	9,  // 21: emailvalidator.v1.ValidatorService.ValidateBatch:input_type -> emailvalidator.v1.ValidateBatchRequest
+	8,  // 22: emailvalidator.v1.ValidatorService.ValidateStream:input_type -> emailvalidator.v1.ValidateRequest
+	10, // 23: emailvalidator.v1.ValidatorService.Validate:output_type -> emailvalidator.v1.ValidateResponse
+	10, // 24: emailvalidator.v1.ValidatorService.ValidateBatch:output_type -> emailvalidator.v1.ValidateResponse
+	10, // 25: emailvalidator.v1.ValidatorService.ValidateStream:output_type -> emailvalidator.v1.ValidateResponse
+	23, // [23:26] is the sub-list for method output_type
+	20, // [20:23] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_validator_proto_init() }
+func file_validator_proto_init() {
+	if File_validator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_validator_proto_rawDesc), len(file_validator_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_validator_proto_goTypes,
+		DependencyIndexes: file_validator_proto_depIdxs,
+		EnumInfos:         file_validator_proto_enumTypes,
+		MessageInfos:      file_validator_proto_msgTypes,
+	}.Build()
+	File_validator_proto = out.File
+	file_validator_proto_goTypes = nil
+	file_validator_proto_depIdxs = nil
+}
@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: validator.proto
+
+package emailvalidatorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ValidatorService_Validate_FullMethodName       = "/emailvalidator.v1.ValidatorService/Validate"
+	ValidatorService_ValidateBatch_FullMethodName  = "/emailvalidator.v1.ValidatorService/ValidateBatch"
+	ValidatorService_ValidateStream_FullMethodName = "/emailvalidator.v1.ValidatorService/ValidateStream"
+)
+
+// ValidatorServiceClient is the client API for ValidatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ValidatorService validates email addresses, the messages mirror the types in the emailvalidator package.
+type ValidatorServiceClient interface {
+	// Validate validates one address.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// ValidateBatch validates the addresses and streams the results in the order of the request.
+	ValidateBatch(ctx context.Context, in *ValidateBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ValidateResponse], error)
+	// ValidateStream validates the addresses as they arrive and streams the results in the same order. the options
+	// of the first request are used for the whole stream.
+	ValidateStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValidateRequest, ValidateResponse], error)
+}
+
+type validatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewValidatorServiceClient(cc grpc.ClientConnInterface) ValidatorServiceClient {
+	return &validatorServiceClient{cc}
+}
+
+func (c *validatorServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, ValidatorService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *validatorServiceClient) ValidateBatch(ctx context.Context, in *ValidateBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ValidateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ValidatorService_ServiceDesc.Streams[0], ValidatorService_ValidateBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ValidateBatchRequest, ValidateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ValidatorService_ValidateBatchClient = grpc.ServerStreamingClient[ValidateResponse]
+
+func (c *validatorServiceClient) ValidateStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ValidateRequest, ValidateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ValidatorService_ServiceDesc.Streams[1], ValidatorService_ValidateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ValidateRequest, ValidateResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ValidatorService_ValidateStreamClient = grpc.BidiStreamingClient[ValidateRequest, ValidateResponse]
+
+// ValidatorServiceServer is the server API for ValidatorService service.
+// All implementations must embed UnimplementedValidatorServiceServer
+// for forward compatibility.
+//
+// ValidatorService validates email addresses, the messages mirror the types in the emailvalidator package.
+type ValidatorServiceServer interface {
+	// Validate validates one address.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// ValidateBatch validates the addresses and streams the results in the order of the request.
+	ValidateBatch(*ValidateBatchRequest, grpc.ServerStreamingServer[ValidateResponse]) error
+	// ValidateStream validates the addresses as they arrive and streams the results in the same order. the options
+	// of the first request are used for the whole stream.
+	ValidateStream(grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]) error
+	mustEmbedUnimplementedValidatorServiceServer()
+}
+
+// UnimplementedValidatorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedValidatorServiceServer struct{}
+
+func (UnimplementedValidatorServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedValidatorServiceServer) ValidateBatch(*ValidateBatchRequest, grpc.ServerStreamingServer[ValidateResponse]) error {
+	return status.Error(codes.Unimplemented, "method ValidateBatch not implemented")
+}
+func (UnimplementedValidatorServiceServer) ValidateStream(grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]) error {
+	return status.Error(codes.Unimplemented, "method ValidateStream not implemented")
+}
+func (UnimplementedValidatorServiceServer) mustEmbedUnimplementedValidatorServiceServer() {}
+func (UnimplementedValidatorServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeValidatorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ValidatorServiceServer will
+// result in compilation errors.
+type UnsafeValidatorServiceServer interface {
+	mustEmbedUnimplementedValidatorServiceServer()
+}
+
+func RegisterValidatorServiceServer(s grpc.ServiceRegistrar, srv ValidatorServiceServer) {
+	// If the following call panics, it indicates UnimplementedValidatorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ValidatorService_ServiceDesc, srv)
+}
+
+func _ValidatorService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValidatorServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ValidatorService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValidatorServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ValidatorService_ValidateBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ValidateBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ValidatorServiceServer).ValidateBatch(m, &grpc.GenericServerStream[ValidateBatchRequest, ValidateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ValidatorService_ValidateBatchServer = grpc.ServerStreamingServer[ValidateResponse]
+
+func _ValidatorService_ValidateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ValidatorServiceServer).ValidateStream(&grpc.GenericServerStream[ValidateRequest, ValidateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ValidatorService_ValidateStreamServer = grpc.BidiStreamingServer[ValidateRequest, ValidateResponse]
+
+// ValidatorService_ServiceDesc is the grpc.ServiceDesc for ValidatorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ValidatorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "emailvalidator.v1.ValidatorService",
+	HandlerType: (*ValidatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _ValidatorService_Validate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ValidateBatch",
+			Handler:       _ValidatorService_ValidateBatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ValidateStream",
+			Handler:       _ValidatorService_ValidateStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "validator.proto",
+}
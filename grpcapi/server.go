@@ -0,0 +1,445 @@
+// Package grpcapi is the gRPC service of the email validator, the service is defined in the
+// emailvalidatorpb/validator.proto.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/fzerorubigd/emailvalidator"
+	pb "github.com/fzerorubigd/emailvalidator/grpcapi/emailvalidatorpb"
+	"github.com/fzerorubigd/emailvalidator/ratelimit"
+)
+
+const (
+	defaultMaxBatchSize   = 1000
+	defaultMaxConcurrency = 8
+)
+
+// Server implements the ValidatorService on a validator
+type Server struct {
+	pb.UnimplementedValidatorServiceServer
+
+	validator      *emailvalidator.Validator
+	opts           []emailvalidator.OptionSetter
+	maxBatchSize   int
+	maxConcurrency int
+	limiter        *ratelimit.Limiter
+	clientKey      func(context.Context) string
+	now            func() time.Time
+}
+
+// Option configures the server
+type Option func(*Server) error
+
+// ValidationOptions sets the options of each validation, they are applied before the options in each request
+func ValidationOptions(opts ...emailvalidator.OptionSetter) Option {
+	return func(s *Server) error {
+		s.opts = append(s.opts, opts...)
+		return nil
+	}
+}
+
+// MaxBatchSize limits the number of the addresses in a ValidateBatch request, the default is 1000
+func MaxBatchSize(n int) Option {
+	return func(s *Server) error {
+		if n < 1 {
+			return errors.New("invalid max batch size")
+		}
+		s.maxBatchSize = n
+		return nil
+	}
+}
+
+// MaxConcurrency limits the concurrency of the batches and the streams, a request that asks for more (or does not set
+// it) gets this concurrency. the default is 8
+func MaxConcurrency(n int) Option {
+	return func(s *Server) error {
+		if n < 1 {
+			return errors.New("invalid max concurrency")
+		}
+		s.maxConcurrency = n
+		return nil
+	}
+}
+
+// RateLimit limits each client with the limiter, an address is one token and a batch larger than the burst of the
+// limiter is rejected. the limiter can be shared with the HTTP API, so a client has the same budget on both. the
+// client is identified by the key function, or by the PeerIP if it is nil
+func RateLimit(l *ratelimit.Limiter, key func(context.Context) string) Option {
+	return func(s *Server) error {
+		if l == nil {
+			return errors.New("nil limiter")
+		}
+		s.limiter = l
+		s.clientKey = key
+		if key == nil {
+			s.clientKey = PeerIP
+		}
+		return nil
+	}
+}
+
+// PeerIP is the default client key of the rate limiter, the IP address of the peer of the connection
+func PeerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+// New creates the service with the options, the default validator is a validator with the built-in checks
+func New(v *emailvalidator.Validator, opts ...Option) (*Server, error) {
+	if v == nil {
+		v = emailvalidator.NewValidator()
+	}
+
+	s := &Server{
+		validator:      v,
+		maxBatchSize:   defaultMaxBatchSize,
+		maxConcurrency: defaultMaxConcurrency,
+		now:            time.Now,
+	}
+	for i := range opts {
+		if err := opts[i](s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// NewServer creates the service with the default limits and without a rate limit, the options are applied before the
+// options in each request
+func NewServer(v *emailvalidator.Validator, opts ...emailvalidator.OptionSetter) *Server {
+	s, _ := New(v, ValidationOptions(opts...))
+	return s
+}
+
+// allow applies the rate limit for n addresses, the error is a ResourceExhausted status
+func (s *Server) allow(ctx context.Context, n int) error {
+	if s.limiter == nil {
+		return nil
+	}
+
+	if n > s.limiter.Burst() {
+		return status.Errorf(codes.ResourceExhausted, "the batch has %d addresses, the rate limit allows %d at once", n, s.limiter.Burst())
+	}
+	if ok, wait := s.limiter.AllowN(s.clientKey(ctx), s.now(), n); !ok {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %ds", int(math.Ceil(wait.Seconds())))
+	}
+
+	return nil
+}
+
+// wait waits for the rate limit of one address, it is used in the streams to slow them down instead of failing
+func (s *Server) wait(ctx context.Context) error {
+	if s.limiter == nil {
+		return nil
+	}
+
+	for {
+		ok, wait := s.limiter.AllowN(s.clientKey(ctx), s.now(), 1)
+		if ok {
+			return nil
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// concurrency returns the concurrency of a request, limited to the MaxConcurrency
+func (s *Server) concurrency(n int32) (emailvalidator.OptionSetter, error) {
+	if n < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid concurrency")
+	}
+	if n == 0 || int(n) > s.maxConcurrency {
+		n = int32(s.maxConcurrency)
+	}
+
+	return emailvalidator.Concurrency(int(n)), nil
+}
+
+func kinds(in []string) []emailvalidator.SpecialUseKind {
+	res := make([]emailvalidator.SpecialUseKind, 0, len(in))
+	for i := range in {
+		res = append(res, emailvalidator.SpecialUseKind(in[i]))
+	}
+
+	return res
+}
+
+func accessList(in *pb.AccessList) emailvalidator.AccessList {
+	return emailvalidator.AccessList{
+		Addresses:        in.GetAddresses(),
+		Domains:          in.GetDomains(),
+		DomainSuffixes:   in.GetDomainSuffixes(),
+		UserNamePatterns: in.GetUsernamePatterns(),
+	}
+}
+
+// options converts the request options, an invalid option is an InvalidArgument error
+func (s *Server) options(in *pb.Options) ([]emailvalidator.OptionSetter, error) {
+	opts := append([]emailvalidator.OptionSetter{}, s.opts...)
+	if in == nil {
+		return opts, nil
+	}
+
+	if mx := in.GetCheckMx(); mx != nil {
+		opts = append(opts, emailvalidator.CheckMX(mx.GetTimeout().AsDuration(), mx.GetForce()))
+	}
+	if g := in.GetGibberishThreshold(); g != nil {
+		opts = append(opts, emailvalidator.GibberishThreshold(g.GetProbability(), int(g.GetMinLength())))
+	}
+	if len(in.GetProtectedDomains()) > 0 {
+		opts = append(opts, emailvalidator.ProtectedDomains(in.GetProtectedDomains()...))
+	}
+	if in.GetAllowList() != nil {
+		opts = append(opts, emailvalidator.AllowList(accessList(in.GetAllowList())))
+	}
+	if in.GetDenyList() != nil {
+		opts = append(opts, emailvalidator.DenyList(accessList(in.GetDenyList())))
+	}
+	if in.GetRejectDenied() {
+		opts = append(opts, emailvalidator.RejectDenied())
+	}
+	if len(in.GetRejectSpecialUse()) > 0 {
+		opts = append(opts, emailvalidator.RejectSpecialUse(kinds(in.GetRejectSpecialUse())...))
+	}
+	if len(in.GetAllowSpecialUse()) > 0 {
+		opts = append(opts, emailvalidator.AllowSpecialUse(kinds(in.GetAllowSpecialUse())...))
+	}
+
+	opt := &emailvalidator.Options{}
+	for i := range opts {
+		if err := opts[i](opt); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid options: %s", err)
+		}
+	}
+
+	return opts, nil
+}
+
+func state(s emailvalidator.ValidationState) pb.ValidationState {
+	switch s {
+	case emailvalidator.ValidationStateTrue:
+		return pb.ValidationState_VALIDATION_STATE_TRUE
+	case emailvalidator.ValidationStateFalse:
+		return pb.ValidationState_VALIDATION_STATE_FALSE
+	}
+
+	return pb.ValidationState_VALIDATION_STATE_NOT_CHECKED
+}
+
+// Result converts the validation result to its protobuf message
+func Result(res *emailvalidator.ValidationResult) *pb.ValidationResult {
+	if res == nil {
+		return nil
+	}
+
+	out := &pb.ValidationResult{
+		FreeProvider:      state(res.FreeProvider),
+		Disposable:        state(res.Disposable),
+		MxValidation:      state(res.MXValidation),
+		BlackList:         state(res.BlackList),
+		BlackListCategory: string(res.BlackListCategory),
+		Gibberish:         state(res.Gibberish),
+		GibberishScore:    res.GibberishScore,
+		MixedScript:       state(res.MixedScript),
+		Homograph:         state(res.Homograph),
+		ImitatedDomain:    res.ImitatedDomain,
+		Denied:            state(res.Denied),
+		SpecialUse:        state(res.SpecialUse),
+		SpecialUseKind:    string(res.SpecialUseKind),
+		MxStatus:          string(res.MXStatus),
+		Typo:              state(res.Typo),
+		Suggestion:        res.Suggestion,
+	}
+	if res.ListMatch != nil {
+		out.ListMatch = &pb.ListMatch{List: res.ListMatch.List, Kind: res.ListMatch.Kind, Value: res.ListMatch.Value}
+	}
+	if res.TLD != nil {
+		out.Tld = &pb.TLDInfo{
+			Name:    res.TLD.Name,
+			Type:    string(res.TLD.Type),
+			Sponsor: res.TLD.Sponsor,
+			Country: res.TLD.Country,
+			Unicode: res.TLD.Unicode,
+		}
+	}
+
+	return out
+}
+
+func response(r emailvalidator.BatchResult) *pb.ValidateResponse {
+	resp := &pb.ValidateResponse{
+		Index:   int64(r.Index),
+		Address: r.Address,
+		Valid:   r.Err == nil,
+		Result:  Result(r.Result),
+	}
+	if r.Err != nil {
+		resp.Error = r.Err.Error()
+	}
+
+	return resp
+}
+
+// Validate validates one address
+func (s *Server) Validate(ctx context.Context, req *pb.ValidateRequest) (*pb.ValidateResponse, error) {
+	opts, err := s.options(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.allow(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	r := emailvalidator.BatchResult{Address: req.GetAddress()}
+	r.Result, r.Err = s.validator.ValidateContext(ctx, req.GetAddress(), opts...)
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return response(r), nil
+}
+
+// ValidateBatch validates the addresses and streams the results in the request order
+func (s *Server) ValidateBatch(req *pb.ValidateBatchRequest, stream pb.ValidatorService_ValidateBatchServer) error {
+	opts, err := s.options(req.GetOptions())
+	if err != nil {
+		return err
+	}
+	c, err := s.concurrency(req.GetConcurrency())
+	if err != nil {
+		return err
+	}
+	opts = append(opts, c)
+	if n := len(req.GetAddresses()); n > s.maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "the batch has %d addresses, the limit is %d", n, s.maxBatchSize)
+	}
+	if err := s.allow(stream.Context(), len(req.GetAddresses())); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	addresses := make(chan string)
+	go func() {
+		defer close(addresses)
+		for _, a := range req.GetAddresses() {
+			select {
+			case addresses <- a:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return send(ctx, cancel, stream, s.validator.ValidateStream(ctx, addresses, opts...))
+}
+
+// ValidateStream validates the addresses as they arrive, the options of the first request are used for the stream.
+// with a rate limit the stream is slowed down to the rate of the client
+func (s *Server) ValidateStream(stream pb.ValidatorService_ValidateStreamServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	opts, err := s.options(first.GetOptions())
+	if err != nil {
+		return err
+	}
+	opts = append(opts, emailvalidator.Concurrency(s.maxConcurrency))
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	addresses := make(chan string)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(addresses)
+		for req := first; ; {
+			if s.wait(ctx) != nil {
+				return
+			}
+			select {
+			case addresses <- req.GetAddress():
+			case <-ctx.Done():
+				return
+			}
+
+			var err error
+			if req, err = stream.Recv(); err != nil {
+				if !errors.Is(err, io.EOF) {
+					recvErr <- err
+					cancel()
+				}
+				return
+			}
+		}
+	}()
+
+	err = send(ctx, cancel, stream, s.validator.ValidateStream(ctx, addresses, opts...))
+	select {
+	case err := <-recvErr:
+		return err
+	default:
+		return err
+	}
+}
+
+// sender is the server side of the ValidateBatch and the ValidateStream streams
+type sender interface {
+	Send(*pb.ValidateResponse) error
+}
+
+// send sends the results to the stream, on a send error the context is canceled and the rest of the results are
+// drained
+func send(ctx context.Context, cancel context.CancelFunc, stream sender, results <-chan emailvalidator.BatchResult) error {
+	var sendErr error
+	for r := range results {
+		if sendErr != nil {
+			continue
+		}
+		if sendErr = stream.Send(response(r)); sendErr != nil {
+			cancel()
+		}
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	return nil
+}
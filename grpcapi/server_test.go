@@ -0,0 +1,191 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/fzerorubigd/emailvalidator/grpcapi/emailvalidatorpb"
+	"github.com/fzerorubigd/emailvalidator/ratelimit"
+)
+
+func newClient(t *testing.T) pb.ValidatorServiceClient {
+	return newServerClient(t, NewServer(nil))
+}
+
+func newServerClient(t *testing.T, s *Server) pb.ValidatorServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterValidatorServiceServer(srv, s)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return pb.NewValidatorServiceClient(conn)
+}
+
+func TestValidate(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	resp, err := c.Validate(ctx, &pb.ValidateRequest{Address: "johnsmith@gmail.com"})
+	require.NoError(t, err)
+	assert.True(t, resp.GetValid())
+	assert.Equal(t, pb.ValidationState_VALIDATION_STATE_TRUE, resp.GetResult().GetFreeProvider())
+	assert.Equal(t, pb.ValidationState_VALIDATION_STATE_NOT_CHECKED, resp.GetResult().GetMxValidation())
+	assert.Equal(t, "com", resp.GetResult().GetTld().GetName())
+
+	resp, err = c.Validate(ctx, &pb.ValidateRequest{Address: "invalid"})
+	require.NoError(t, err)
+	assert.False(t, resp.GetValid())
+	assert.NotEmpty(t, resp.GetError())
+	assert.Nil(t, resp.GetResult())
+
+	resp, err = c.Validate(ctx, &pb.ValidateRequest{
+		Address: "johnsmith@gmail.com",
+		Options: &pb.Options{DenyList: &pb.AccessList{Domains: []string{"gmail.com"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pb.ValidationState_VALIDATION_STATE_TRUE, resp.GetResult().GetDenied())
+	assert.Equal(t, "deny", resp.GetResult().GetListMatch().GetList())
+
+	_, err = c.Validate(ctx, &pb.ValidateRequest{
+		Address: "johnsmith@gmail.com",
+		Options: &pb.Options{GibberishThreshold: &pb.GibberishThreshold{Probability: 2}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestValidateBatch(t *testing.T) {
+	c := newClient(t)
+	addresses := []string{"johnsmith@gmail.com", "invalid", "info@mailinator.com"}
+	stream, err := c.ValidateBatch(context.Background(), &pb.ValidateBatchRequest{Addresses: addresses, Concurrency: 2})
+	require.NoError(t, err)
+
+	var res []*pb.ValidateResponse
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		res = append(res, r)
+	}
+
+	require.Len(t, res, 3)
+	for i := range res {
+		assert.Equal(t, int64(i), res[i].GetIndex())
+		assert.Equal(t, addresses[i], res[i].GetAddress())
+	}
+	assert.False(t, res[1].GetValid())
+	assert.Equal(t, pb.ValidationState_VALIDATION_STATE_TRUE, res[2].GetResult().GetDisposable())
+
+	stream, err = c.ValidateBatch(context.Background(), &pb.ValidateBatchRequest{Addresses: addresses, Concurrency: -1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestValidateStream(t *testing.T) {
+	c := newClient(t)
+	stream, err := c.ValidateStream(context.Background())
+	require.NoError(t, err)
+
+	addresses := []string{"johnsmith@gmail.com", "invalid", "info@mailinator.com"}
+	require.NoError(t, stream.Send(&pb.ValidateRequest{
+		Address: addresses[0],
+		Options: &pb.Options{AllowList: &pb.AccessList{Domains: []string{"mailinator.com"}}},
+	}))
+	r, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, addresses[0], r.GetAddress())
+
+	for _, a := range addresses[1:] {
+		require.NoError(t, stream.Send(&pb.ValidateRequest{Address: a}))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var res []*pb.ValidateResponse
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		res = append(res, r)
+	}
+
+	require.Len(t, res, 2)
+	assert.Equal(t, int64(1), res[0].GetIndex())
+	assert.False(t, res[0].GetValid())
+	assert.Equal(t, int64(2), res[1].GetIndex())
+	assert.Equal(t, pb.ValidationState_VALIDATION_STATE_FALSE, res[1].GetResult().GetDisposable())
+	assert.Equal(t, "allow", res[1].GetResult().GetListMatch().GetList())
+}
+
+func TestServerLimits(t *testing.T) {
+	l, err := ratelimit.New(1, 2)
+	require.NoError(t, err)
+	s, err := New(nil, MaxBatchSize(3), MaxConcurrency(2), RateLimit(l, func(context.Context) string { return "client" }))
+	require.NoError(t, err)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	c := newServerClient(t, s)
+	ctx := context.Background()
+
+	batch := func(addresses ...string) error {
+		stream, err := c.ValidateBatch(ctx, &pb.ValidateBatchRequest{Addresses: addresses, Concurrency: 10})
+		require.NoError(t, err)
+		for {
+			if _, err := stream.Recv(); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(batch("a@gmail.com", "b@gmail.com", "c@gmail.com", "d@gmail.com")))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(batch("a@gmail.com", "b@gmail.com", "c@gmail.com")))
+
+	// the limiter is shared, the tokens taken by the other services are not available
+	ok, _ := l.AllowN("client", now, 1)
+	require.True(t, ok)
+	_, err = c.Validate(ctx, &pb.ValidateRequest{Address: "johnsmith@gmail.com"})
+	require.NoError(t, err)
+	_, err = c.Validate(ctx, &pb.ValidateRequest{Address: "johnsmith@gmail.com"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	now = now.Add(2 * time.Second)
+	assert.NoError(t, batch("johnsmith@gmail.com", "janesmith@gmail.com"))
+
+	_, err = New(nil, MaxBatchSize(0))
+	assert.Error(t, err)
+	_, err = New(nil, MaxConcurrency(0))
+	assert.Error(t, err)
+	_, err = New(nil, RateLimit(nil, nil))
+	assert.Error(t, err)
+}
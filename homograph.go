@@ -0,0 +1,158 @@
+package emailvalidator
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+var (
+	freeProviderSkeletonOnce sync.Once
+	freeProviderSkeleton     map[string]string
+)
+
+// cjkScripts are the scripts that are allowed to be mixed with Latin, based on the highly restrictive level in the
+// Unicode TR39 (https://www.unicode.org/reports/tr39/#Restriction_Level_Detection)
+var cjkScripts = [][]*unicode.RangeTable{
+	{unicode.Han, unicode.Hiragana, unicode.Katakana},
+	{unicode.Han, unicode.Bopomofo},
+	{unicode.Han, unicode.Hangul},
+}
+
+// ProtectedDomains adds the domains to the list of domains that are checked for imitation and typos, the free
+// providers are always in this list
+func ProtectedDomains(domains ...string) OptionSetter {
+	return func(opt *Options) error {
+		for i := range domains {
+			opt.protectedDomains = append(opt.protectedDomains, strings.ToLower(domains[i]))
+		}
+		return nil
+	}
+}
+
+func scriptOf(r rune) *unicode.RangeTable {
+	if r < unicode.MaxASCII && !unicode.IsLetter(r) || unicode.In(r, unicode.Common, unicode.Inherited) {
+		return nil
+	}
+
+	if unicode.Is(unicode.Latin, r) {
+		return unicode.Latin
+	}
+
+	for _, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return table
+		}
+	}
+
+	return nil
+}
+
+func inScripts(table *unicode.RangeTable, scripts []*unicode.RangeTable) bool {
+	for i := range scripts {
+		if scripts[i] == table {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMixedScript returns true if the string contains characters from more than one script, the common and inherited
+// characters (like digits and dash) are ignored. Latin mixed with the CJK scripts used in one language is allowed.
+func isMixedScript(s string) bool {
+	var scripts []*unicode.RangeTable
+	for _, r := range s {
+		table := scriptOf(r)
+		if table == nil || inScripts(table, scripts) {
+			continue
+		}
+		scripts = append(scripts, table)
+	}
+
+	if len(scripts) < 2 {
+		return false
+	}
+
+	for _, cjk := range cjkScripts {
+		mixed := false
+		for _, table := range scripts {
+			if table != unicode.Latin && !inScripts(table, cjk) {
+				mixed = true
+				break
+			}
+		}
+		if !mixed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func skeleton(domain string) string {
+	if u, err := idna.ToUnicode(domain); err == nil {
+		domain = u
+	}
+
+	return confusableSkeleton(strings.ToLower(domain))
+}
+
+// imitatedDomain returns the protected domain (or free provider) that has the same skeleton as the domain
+func imitatedDomain(domain string, protected []string) string {
+	freeProviderSkeletonOnce.Do(func() {
+		freeProviderSkeleton = make(map[string]string, freeProvider.len())
+		freeProvider.each(func(d string) {
+			freeProviderSkeleton[skeleton(d)] = d
+		})
+	})
+
+	domain = strings.ToLower(domain)
+	if isFreeProvider(domain) {
+		return ""
+	}
+
+	for i := range protected {
+		if protected[i] == domain {
+			return ""
+		}
+	}
+
+	s := skeleton(domain)
+	for i := range protected {
+		if skeleton(protected[i]) == s {
+			return protected[i]
+		}
+	}
+
+	return freeProviderSkeleton[s]
+}
+
+func checkHomograph(_ context.Context, in *Input, res *ValidationResult) error {
+	res.MixedScript = ValidationStateFalse
+	res.Homograph = ValidationStateFalse
+	if isAllowed(res) {
+		return nil
+	}
+
+	labels := strings.Split(in.Domain, ".")
+	for _, part := range append(labels, in.UserName) {
+		if u, err := idna.ToUnicode(part); err == nil {
+			part = u
+		}
+		if isMixedScript(part) {
+			res.MixedScript = ValidationStateTrue
+			break
+		}
+	}
+
+	if d := imitatedDomain(in.Domain, in.opt.protectedDomains); d != "" {
+		res.Homograph = ValidationStateTrue
+		res.ImitatedDomain = d
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMixedScript(t *testing.T) {
+	assert.False(t, isMixedScript("paypal"))
+	assert.False(t, isMixedScript("пример"))
+	assert.False(t, isMixedScript("bücher-2020"))
+	assert.False(t, isMixedScript("東京tokyoとうきょう"))
+	assert.False(t, isMixedScript("서울seoul漢字"))
+	assert.True(t, isMixedScript("pаypal"))
+	assert.True(t, isMixedScript("ѕеcurity"))
+	assert.True(t, isMixedScript("ひらがなпривет"))
+}
+
+func TestConfusableSkeleton(t *testing.T) {
+	assert.Equal(t, confusableSkeleton("paypal"), confusableSkeleton("pаypal"))
+	assert.Equal(t, confusableSkeleton("modern"), confusableSkeleton("rnodern"))
+	assert.Equal(t, confusableSkeleton("gmail"), confusableSkeleton("gmai1"))
+	assert.Equal(t, confusableSkeleton("paypal"), confusableSkeleton("pay\u00adpal"))
+	assert.NotEqual(t, confusableSkeleton("paypal"), confusableSkeleton("paypai"))
+	assert.Equal(t, "", confusableSkeleton(""))
+}
+
+func TestHomograph(t *testing.T) {
+	res, err := Validate("john@pаypal.com", ProtectedDomains("PayPal.com"))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.MixedScript)
+	assert.Equal(t, ValidationStateTrue, res.Homograph)
+	assert.Equal(t, "paypal.com", res.ImitatedDomain)
+
+	res, err = Validate("john@xn--pypal-4ve.com", ProtectedDomains("paypal.com"))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.MixedScript)
+	assert.Equal(t, ValidationStateTrue, res.Homograph)
+	assert.Equal(t, "paypal.com", res.ImitatedDomain)
+
+	res, err = Validate("john@paypal.com", ProtectedDomains("paypal.com"))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.MixedScript)
+	assert.Equal(t, ValidationStateFalse, res.Homograph)
+	assert.Empty(t, res.ImitatedDomain)
+
+	res, err = Validate("john@pаypal.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.MixedScript)
+	assert.Equal(t, ValidationStateFalse, res.Homograph)
+
+	res, err = Validate("john@gmai1.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.MixedScript)
+	assert.Equal(t, ValidationStateTrue, res.Homograph)
+	assert.Equal(t, "gmail.com", res.ImitatedDomain)
+
+	res, err = Validate("jоhn.smith@yahoo.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.MixedScript)
+	assert.Equal(t, ValidationStateFalse, res.Homograph)
+}
@@ -0,0 +1,288 @@
+// Package httpapi is a JSON API over HTTP for the email validator, so the services in other languages get the same
+// verdicts. the results use the json format of the emailvalidator.ValidationResult.
+//
+//	GET  /validate?email=user@example.com
+//	POST /validate  {"emails": ["user@example.com", ...]}
+//	GET  /healthz
+//	GET  /readyz
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/fzerorubigd/emailvalidator"
+	"github.com/fzerorubigd/emailvalidator/ratelimit"
+)
+
+const (
+	defaultMaxBodySize  = 1 << 20
+	defaultMaxBatchSize = 1000
+)
+
+// Result is the validation result of one address
+type Result struct {
+	Address string                           `json:"address"`
+	Valid   bool                             `json:"valid"`
+	Error   string                           `json:"error,omitempty"`
+	Result  *emailvalidator.ValidationResult `json:"result,omitempty"`
+}
+
+// BatchRequest is the body of the batch request
+type BatchRequest struct {
+	Emails []string `json:"emails"`
+}
+
+// BatchResponse is the response of the batch request, the results are in the order of the request
+type BatchResponse struct {
+	Results []Result `json:"results"`
+}
+
+// ErrorResponse is the body of the responses with an error status
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler serves the validation API, it is safe for concurrent use
+type Handler struct {
+	validator    *emailvalidator.Validator
+	opts         []emailvalidator.OptionSetter
+	maxBodySize  int64
+	maxBatchSize int
+	limiter      *ratelimit.Limiter
+	clientKey    func(*http.Request) string
+	now          func() time.Time
+	ready        atomic.Bool
+	mux          *http.ServeMux
+}
+
+// Option configures the handler
+type Option func(*Handler) error
+
+// WithValidator sets the validator, the default is a validator with the built-in checks
+func WithValidator(v *emailvalidator.Validator) Option {
+	return func(h *Handler) error {
+		if v == nil {
+			return errors.New("nil validator")
+		}
+		h.validator = v
+		return nil
+	}
+}
+
+// ValidationOptions sets the options passed to each validation
+func ValidationOptions(opts ...emailvalidator.OptionSetter) Option {
+	return func(h *Handler) error {
+		h.opts = append(h.opts, opts...)
+		return nil
+	}
+}
+
+// MaxBodySize limits the size of the request body in bytes, the default is 1MB
+func MaxBodySize(n int64) Option {
+	return func(h *Handler) error {
+		if n < 1 {
+			return errors.New("invalid max body size")
+		}
+		h.maxBodySize = n
+		return nil
+	}
+}
+
+// MaxBatchSize limits the number of the addresses in a batch request, the default is 1000
+func MaxBatchSize(n int) Option {
+	return func(h *Handler) error {
+		if n < 1 {
+			return errors.New("invalid max batch size")
+		}
+		h.maxBatchSize = n
+		return nil
+	}
+}
+
+// RateLimit limits each client to rate addresses per second, with bursts of up to burst addresses. a batch request
+// counts as the number of its addresses, and a batch larger than the burst is rejected. the client is identified by
+// the key function, or by the RemoteIP if it is nil
+func RateLimit(rate float64, burst int, key func(*http.Request) string) Option {
+	return func(h *Handler) error {
+		l, err := ratelimit.New(rate, burst)
+		if err != nil {
+			return err
+		}
+		return WithLimiter(l, key)(h)
+	}
+}
+
+// WithLimiter is the RateLimit with a limiter that can be shared with the other services, like the gRPC server
+func WithLimiter(l *ratelimit.Limiter, key func(*http.Request) string) Option {
+	return func(h *Handler) error {
+		if l == nil {
+			return errors.New("nil limiter")
+		}
+		h.limiter = l
+		h.clientKey = key
+		if key == nil {
+			h.clientKey = RemoteIP
+		}
+		return nil
+	}
+}
+
+// NewHandler creates the API handler, the handler is ready after the embedded data is loaded with a validation
+func NewHandler(opts ...Option) (*Handler, error) {
+	h := &Handler{
+		validator:    emailvalidator.NewValidator(),
+		maxBodySize:  defaultMaxBodySize,
+		maxBatchSize: defaultMaxBatchSize,
+		now:          time.Now,
+		mux:          http.NewServeMux(),
+	}
+	for i := range opts {
+		if err := opts[i](h); err != nil {
+			return nil, err
+		}
+	}
+
+	h.mux.HandleFunc("/validate", h.validate)
+	h.mux.HandleFunc("/healthz", h.health)
+	h.mux.HandleFunc("/readyz", h.readiness)
+
+	// the data is indexed on the first use, validating an address loads it before the handler is ready
+	_, _ = h.validator.Validate("warmup@example.com")
+	h.ready.Store(true)
+
+	return h, nil
+}
+
+// SetReady changes the readiness of the handler, a server sets it to false before the shutdown to drain the traffic
+func (h *Handler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// ServeHTTP implements the http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, ErrorResponse{Error: fmt.Sprintf(format, args...)})
+}
+
+func newResult(address string, res *emailvalidator.ValidationResult, err error) Result {
+	r := Result{Address: address, Valid: err == nil, Result: res}
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	return r
+}
+
+// allow applies the rate limit for n addresses, it writes the error response if the client is limited
+func (h *Handler) allow(w http.ResponseWriter, r *http.Request, n int) bool {
+	if h.limiter == nil {
+		return true
+	}
+
+	if n > h.limiter.Burst() {
+		writeError(w, http.StatusRequestEntityTooLarge, "the batch has %d emails, the rate limit allows %d at once", n, h.limiter.Burst())
+		return false
+	}
+
+	ok, wait := h.limiter.AllowN(h.clientKey(r), h.now(), n)
+	if !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+	}
+
+	return ok
+}
+
+func (h *Handler) validate(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.validateOne(w, r)
+	case http.MethodPost:
+		h.validateBatch(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+}
+
+func (h *Handler) validateOne(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("email")
+	if address == "" {
+		writeError(w, http.StatusBadRequest, "the email parameter is required")
+		return
+	}
+	if !h.allow(w, r, 1) {
+		return
+	}
+
+	res, err := h.validator.ValidateContext(r.Context(), address, h.opts...)
+	writeJSON(w, http.StatusOK, newResult(address, res, err))
+}
+
+func (h *Handler) validateBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize)).Decode(&req); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, "the body is larger than %d bytes", h.maxBodySize)
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid body: %s", err)
+		return
+	}
+
+	if len(req.Emails) == 0 {
+		writeError(w, http.StatusBadRequest, "the emails are required")
+		return
+	}
+	if len(req.Emails) > h.maxBatchSize {
+		writeError(w, http.StatusRequestEntityTooLarge, "the batch has %d emails, the limit is %d", len(req.Emails), h.maxBatchSize)
+		return
+	}
+	if !h.allow(w, r, len(req.Emails)) {
+		return
+	}
+
+	results := h.validator.ValidateBatch(r.Context(), slices.Values(req.Emails), h.opts...)
+	if err := r.Context().Err(); err != nil {
+		return
+	}
+
+	resp := BatchResponse{Results: make([]Result, 0, len(results))}
+	for _, res := range results {
+		resp.Results = append(resp.Results, newResult(res.Address, res.Result, res.Err))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) health(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (h *Handler) readiness(w http.ResponseWriter, _ *http.Request) {
+	if !h.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "not ready"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+var _ http.Handler = (*Handler)(nil)
@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func request(t *testing.T, h http.Handler, method, target, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return rec, res
+}
+
+func TestValidate(t *testing.T) {
+	h, err := NewHandler()
+	require.NoError(t, err)
+
+	rec, res := request(t, h, http.MethodGet, "/validate?email=johnsmith@gmail.com", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, true, res["valid"])
+	result := res["result"].(map[string]interface{})
+	assert.Equal(t, true, result["free_provider"])
+	assert.Nil(t, result["mx_validation"])
+
+	rec, res = request(t, h, http.MethodGet, "/validate?email=invalid", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, false, res["valid"])
+	assert.NotEmpty(t, res["error"])
+
+	rec, _ = request(t, h, http.MethodGet, "/validate", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = request(t, h, http.MethodDelete, "/validate", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestValidateBatch(t *testing.T) {
+	h, err := NewHandler(MaxBatchSize(2), MaxBodySize(100))
+	require.NoError(t, err)
+
+	rec, res := request(t, h, http.MethodPost, "/validate", `{"emails": ["johnsmith@gmail.com", "invalid"]}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	results := res["results"].([]interface{})
+	require.Len(t, results, 2)
+	assert.Equal(t, "johnsmith@gmail.com", results[0].(map[string]interface{})["address"])
+	assert.Equal(t, false, results[1].(map[string]interface{})["valid"])
+
+	rec, _ = request(t, h, http.MethodPost, "/validate", `{"emails": ["a@gmail.com", "b@gmail.com", "c@gmail.com"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec, _ = request(t, h, http.MethodPost, "/validate", `{"emails": ["`+strings.Repeat("a", 100)+`@gmail.com"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec, _ = request(t, h, http.MethodPost, "/validate", `{"emails": []}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = request(t, h, http.MethodPost, "/validate", `not json`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHealth(t *testing.T) {
+	h, err := NewHandler()
+	require.NoError(t, err)
+
+	rec, _ := request(t, h, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec, _ = request(t, h, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	h.SetReady(false)
+	rec, _ = request(t, h, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	rec, _ = request(t, h, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRateLimit(t *testing.T) {
+	h, err := NewHandler(RateLimit(1, 2, nil))
+	require.NoError(t, err)
+
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		rec, _ := request(t, h, http.MethodGet, "/validate?email=johnsmith@gmail.com", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	rec, _ := request(t, h, http.MethodGet, "/validate?email=johnsmith@gmail.com", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	now = now.Add(time.Second)
+	rec, _ = request(t, h, http.MethodGet, "/validate?email=johnsmith@gmail.com", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	now = now.Add(2 * time.Second)
+	rec, _ = request(t, h, http.MethodPost, "/validate", `{"emails": ["johnsmith@gmail.com", "janesmith@gmail.com", "bobsmith@gmail.com"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	rec, _ = request(t, h, http.MethodPost, "/validate", `{"emails": ["johnsmith@gmail.com", "janesmith@gmail.com"]}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec, _ = request(t, h, http.MethodGet, "/validate?email=johnsmith@gmail.com", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// the batch is charged for all of its addresses
+	now = now.Add(time.Second)
+	rec, _ = request(t, h, http.MethodPost, "/validate", `{"emails": ["johnsmith@gmail.com", "janesmith@gmail.com"]}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	_, err = NewHandler(RateLimit(0, 1, nil))
+	assert.Error(t, err)
+}
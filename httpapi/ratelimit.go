@@ -0,0 +1,16 @@
+package httpapi
+
+import (
+	"net"
+	"net/http"
+)
+
+// RemoteIP is the default client key of the rate limiter, the IP address of the remote end of the connection
+func RemoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
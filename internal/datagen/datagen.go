@@ -0,0 +1,109 @@
+// Package datagen is the parsing and the merging of the upstream lists, it is used by the generate.go that generates
+// the embedded data
+package datagen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OverrideSource is the provenance name of the domains in the include override files
+const OverrideSource = "override"
+
+// Source is the content of an upstream list, the Name is recorded as the provenance of its domains and the File is
+// used in the errors
+type Source struct {
+	Name   string
+	File   string
+	Format string
+	Data   []byte
+}
+
+// Parse returns the domains in the data, the format is json (an array of strings), php (the quoted lines of a php
+// array) or lines (one domain per line, the lines starting with # are ignored)
+func Parse(format string, data []byte) ([]string, error) {
+	switch format {
+	case "json":
+		return parseJSON(data)
+	case "php":
+		return parsePHP(data)
+	case "lines":
+		return ParseLines(data)
+	}
+
+	return nil, fmt.Errorf("format %q is not supported", format)
+}
+
+// ParseLines returns the lower case lines of the data, without the empty lines and the comments
+func ParseLines(data []byte) ([]string, error) {
+	var res []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		s := strings.Trim(scanner.Text(), "\r\n\t ")
+		if len(s) < 1 {
+			continue
+		}
+
+		if s[0] != '#' {
+			res = append(res, strings.ToLower(strings.Trim(s, "',")))
+		}
+	}
+
+	return res, scanner.Err()
+}
+
+func parseJSON(data []byte) ([]string, error) {
+	var res []string
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func parsePHP(data []byte) ([]string, error) {
+	var res []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		s := strings.Trim(scanner.Text(), "\r\n\t ")
+		if len(s) < 1 {
+			continue
+		}
+
+		if s[0] == '\'' || s[0] == '"' {
+			res = append(res, strings.Trim(s, `"',`))
+		}
+	}
+
+	return res, scanner.Err()
+}
+
+// Merge returns the union of the sources, and the bit mask of the index of the sources for each domain. the
+// domains in the include override file are added with the OverrideSource and the domains in the exclude file are
+// removed
+func Merge(sources []Source, index map[string]uint, include, exclude []string) (map[string]uint32, error) {
+	res := make(map[string]uint32)
+	for _, s := range sources {
+		list, err := Parse(s.Format, s.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", s.File, err)
+		}
+		for _, d := range list {
+			res[strings.ToLower(strings.TrimSpace(d))] |= 1 << index[s.Name]
+		}
+	}
+
+	for _, d := range include {
+		res[d] |= 1 << index[OverrideSource]
+	}
+
+	for _, d := range exclude {
+		delete(res, d)
+	}
+
+	delete(res, "")
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package datagen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	list, err := Parse("json", []byte(`["a.com", "B.com"]`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.com", "B.com"}, list)
+
+	list, err = Parse("php", []byte("<?php\nreturn [\n  'a.com',\n  \"b.com\",\n];\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.com", "b.com"}, list)
+
+	list, err = Parse("lines", []byte("# comment\nA.com\n\n b.com \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.com", "b.com"}, list)
+
+	_, err = Parse("xml", nil)
+	require.Error(t, err)
+}
+
+func TestMerge(t *testing.T) {
+	index := map[string]uint{"first": 0, "second": 1, OverrideSource: 2}
+	sources := []Source{
+		{Name: "first", File: "first.json", Format: "json", Data: []byte(`["a.com", "B.com", "c.com"]`)},
+		{Name: "second", File: "second.txt", Format: "lines", Data: []byte("b.com\nd.com\nc.com\n")},
+	}
+
+	res, err := Merge(sources, index, []string{"d.com", "e.com"}, []string{"c.com"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint32{
+		"a.com": 1,
+		"b.com": 1 | 2,
+		"d.com": 2 | 4,
+		"e.com": 4,
+	}, res)
+
+	_, err = Merge([]Source{{Name: "first", File: "first.json", Format: "json", Data: []byte("{")}}, index, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "first.json")
+}
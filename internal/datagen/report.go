@@ -0,0 +1,141 @@
+package datagen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Diff is the change of one list between the previous and the current run
+type Diff struct {
+	Name     string
+	Previous int
+	Current  int
+	Added    []string
+	Removed  []string
+}
+
+// RemovalRatio is the ratio of the removed domains to the domains in the previous run
+func (d Diff) RemovalRatio() float64 {
+	if d.Previous == 0 {
+		return 0
+	}
+
+	return float64(len(d.Removed)) / float64(d.Previous)
+}
+
+// SortedKeys returns the domains of the list in order
+func SortedKeys(data map[string]uint32) []string {
+	res := make([]string, 0, len(data))
+	for k := range data {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// DiffList returns the domains added to and removed from the list, in order
+func DiffList(name string, prev map[string]bool, cur map[string]uint32) Diff {
+	d := Diff{Name: name, Previous: len(prev), Current: len(cur)}
+	for _, k := range SortedKeys(cur) {
+		if !prev[k] {
+			d.Added = append(d.Added, k)
+		}
+	}
+	for k := range prev {
+		if _, ok := cur[k]; !ok {
+			d.Removed = append(d.Removed, k)
+		}
+	}
+	sort.Strings(d.Removed)
+
+	return d
+}
+
+// Moved returns the domains that were in the from list and not in the to list in the previous run, and are in the
+// to list and not in the from list now
+func Moved(prevFrom, prevTo map[string]bool, from, to map[string]uint32) []string {
+	var res []string
+	for _, k := range SortedKeys(to) {
+		if _, ok := from[k]; prevFrom[k] && !prevTo[k] && !ok {
+			res = append(res, k)
+		}
+	}
+
+	return res
+}
+
+// Report is the change of the lists between the previous and the current run. the Diffs are nil if there is no
+// previous run
+type Report struct {
+	Diffs            []Diff
+	FreeToDisposable []string
+	DisposableToFree []string
+}
+
+// NewReport compares the lists by the names, the prev is nil on the first run. the domains moved between the free
+// and disposable lists are reported separately
+func NewReport(names []string, prev map[string]map[string]bool, lists map[string]map[string]uint32) *Report {
+	r := &Report{}
+	if prev == nil {
+		return r
+	}
+
+	for _, name := range names {
+		r.Diffs = append(r.Diffs, DiffList(name, prev[name], lists[name]))
+	}
+	r.FreeToDisposable = Moved(prev["free"], prev["disposable"], lists["free"], lists["disposable"])
+	r.DisposableToFree = Moved(prev["disposable"], prev["free"], lists["disposable"], lists["free"])
+
+	return r
+}
+
+// Check returns an error if a list lost more than the maxRemoval ratio of its domains
+func (r *Report) Check(maxRemoval float64) error {
+	for _, d := range r.Diffs {
+		if ratio := d.RemovalRatio(); ratio > maxRemoval {
+			return fmt.Errorf("%s lost %d of %d domains (%.1f%%), more than the -max-removal, the data is not changed",
+				d.Name, len(d.Removed), d.Previous, ratio*100)
+		}
+	}
+
+	return nil
+}
+
+func writeDomains(w io.Writer, title string, domains []string) {
+	if len(domains) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%s (%d):\n", title, len(domains))
+	for _, d := range domains {
+		_, _ = fmt.Fprintf(w, "  %s\n", d)
+	}
+}
+
+// Write writes the report, the count of each list and then the domains added and removed in each list, the domains
+// moved between the free and disposable lists and the tlds that appeared or were retired
+func (r *Report) Write(w io.Writer) {
+	if r.Diffs == nil {
+		_, _ = fmt.Fprintln(w, "There is no previous data to compare with.")
+		return
+	}
+
+	for _, d := range r.Diffs {
+		_, _ = fmt.Fprintf(w, "%s: %d -> %d, %d added, %d removed\n", d.Name, d.Previous, d.Current, len(d.Added), len(d.Removed))
+	}
+
+	for _, d := range r.Diffs {
+		if d.Name == "tlds" {
+			writeDomains(w, "TLDs appeared", d.Added)
+			writeDomains(w, "TLDs retired", d.Removed)
+			continue
+		}
+		writeDomains(w, "Added to "+d.Name, d.Added)
+		writeDomains(w, "Removed from "+d.Name, d.Removed)
+	}
+
+	writeDomains(w, "Moved from free to disposable", r.FreeToDisposable)
+	writeDomains(w, "Moved from disposable to free", r.DisposableToFree)
+}
@@ -0,0 +1,87 @@
+package datagen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func set(domains ...string) map[string]bool {
+	res := make(map[string]bool)
+	for _, d := range domains {
+		res[d] = true
+	}
+	return res
+}
+
+func list(domains ...string) map[string]uint32 {
+	res := make(map[string]uint32)
+	for _, d := range domains {
+		res[d] = 1
+	}
+	return res
+}
+
+func TestDiffList(t *testing.T) {
+	d := DiffList("free", set("a.com", "b.com", "c.com", "d.com"), list("e.com", "a.com", "b.com"))
+	assert.Equal(t, []string{"e.com"}, d.Added)
+	assert.Equal(t, []string{"c.com", "d.com"}, d.Removed)
+	assert.Equal(t, 4, d.Previous)
+	assert.Equal(t, 3, d.Current)
+	assert.Equal(t, 0.5, d.RemovalRatio())
+
+	assert.Equal(t, 0.0, DiffList("free", nil, list("a.com")).RemovalRatio())
+}
+
+func TestMoved(t *testing.T) {
+	prevFree, prevDisposable := set("a.com", "b.com", "both.com"), set("c.com", "both.com")
+	free, disposable := list("b.com", "c.com"), list("a.com", "both.com", "new.com")
+
+	assert.Equal(t, []string{"a.com"}, Moved(prevFree, prevDisposable, free, disposable))
+	assert.Equal(t, []string{"c.com"}, Moved(prevDisposable, prevFree, disposable, free))
+
+	// a domain that is still in the from list did not move
+	free["a.com"] = 1
+	assert.Empty(t, Moved(prevFree, prevDisposable, free, disposable))
+}
+
+func TestReport(t *testing.T) {
+	names := []string{"disposable", "free", "tlds"}
+	prev := map[string]map[string]bool{
+		"disposable": set("a.com", "b.com"),
+		"free":       set("c.com", "d.com", "e.com", "f.com"),
+		"tlds":       set("com", "old"),
+	}
+	lists := map[string]map[string]uint32{
+		"disposable": list("a.com", "b.com", "c.com"),
+		"free":       list("d.com", "e.com", "f.com"),
+		"tlds":       list("com", "new"),
+	}
+
+	r := NewReport(names, prev, lists)
+	require.Len(t, r.Diffs, 3)
+	assert.Equal(t, []string{"c.com"}, r.FreeToDisposable)
+	assert.Empty(t, r.DisposableToFree)
+
+	b := &strings.Builder{}
+	r.Write(b)
+	out := b.String()
+	assert.Contains(t, out, "free: 4 -> 3, 0 added, 1 removed\n")
+	assert.Contains(t, out, "\nMoved from free to disposable (1):\n  c.com\n")
+	assert.Contains(t, out, "\nTLDs appeared (1):\n  new\n")
+	assert.Contains(t, out, "\nTLDs retired (1):\n  old\n")
+
+	assert.NoError(t, r.Check(0.5))
+	err := r.Check(0.2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "free lost 1 of 4 domains (25.0%)")
+
+	r = NewReport(names, nil, lists)
+	assert.Nil(t, r.Diffs)
+	assert.NoError(t, r.Check(0))
+	b.Reset()
+	r.Write(b)
+	assert.Equal(t, "There is no previous data to compare with.\n", b.String())
+}
@@ -0,0 +1,106 @@
+// Package gibberish is the feature extraction of the gibberish model, it is shared by the emailvalidator package and
+// the generate_gibberish.go that trains the model, so the features of the training and the detection are the same
+package gibberish
+
+import "math"
+
+const (
+	// Alphabet is the size of the bigram table, a-z and the word boundary
+	Alphabet = 27
+	// Boundary is the index of the word boundary in the bigram table
+	Boundary = 26
+	// Features is the number of the features
+	Features = 5
+)
+
+// Bigrams is the log probability of each character after another one, the last index is the word boundary
+type Bigrams [Alphabet][Alphabet]float64
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// Extract returns the features of the lower case user name: the average negative log likelihood of the character
+// bigrams, the vowel ratio, the digit density, the normalized Shannon entropy and the density of switches between
+// digits and letters
+func Extract(model *Bigrams, u string) [Features]float64 {
+	var (
+		letters, digits, vowels, transitions int
+		switches                             int
+		digit                                bool
+		logProb                              float64
+		freq                                 [256]int
+		distinct                             []byte
+	)
+	prev := Boundary
+	for i := 0; i < len(u); i++ {
+		c := u[i]
+		if freq[c] == 0 {
+			distinct = append(distinct, c)
+		}
+		freq[c]++
+		isDigit := c >= '0' && c <= '9'
+		if i > 0 && isDigit != digit {
+			switches++
+		}
+		digit = isDigit
+		switch {
+		case c >= 'a' && c <= 'z':
+			letters++
+			if isVowel(c) {
+				vowels++
+			}
+			logProb += model[prev][c-'a']
+			transitions++
+			prev = int(c - 'a')
+			continue
+		case isDigit:
+			digits++
+		}
+		if prev != Boundary {
+			logProb += model[prev][Boundary]
+			transitions++
+			prev = Boundary
+		}
+	}
+	if prev != Boundary {
+		logProb += model[prev][Boundary]
+		transitions++
+	}
+
+	var f [Features]float64
+	if transitions > 0 {
+		f[0] = -logProb / float64(transitions)
+	}
+	if letters > 0 {
+		f[1] = float64(vowels) / float64(letters)
+	}
+	f[2] = float64(digits) / float64(len(u))
+	f[4] = float64(switches) / float64(len(u))
+	if len(u) > 1 {
+		var entropy float64
+		for _, c := range distinct {
+			p := float64(freq[c]) / float64(len(u))
+			entropy -= p * math.Log2(p)
+		}
+		f[3] = entropy / math.Log2(float64(len(u)))
+	}
+
+	return f
+}
+
+// Probability returns the probability of the user name being randomly generated, the weights are the bias and the
+// weight of each feature
+func Probability(model *Bigrams, weights *[Features + 1]float64, u string) float64 {
+	f := Extract(model, u)
+	z := weights[0]
+	for i := range f {
+		z += weights[i+1] * f[i]
+	}
+
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,140 @@
+package emailvalidator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// LocalPart is how the part of the address before the @ is written in the logs
+type LocalPart int
+
+const (
+	// LocalPartRedact replaces the local part with "***", it is the default
+	LocalPartRedact LocalPart = iota
+	// LocalPartHash replaces the local part with a prefix of its sha256, so the lines of an address can be matched
+	// without logging it. the hash is not salted, a short local part can be guessed from it
+	LocalPartHash
+	// LocalPartPlain logs the full address
+	LocalPartPlain
+)
+
+// checkSignals is the signal set by the built-in checks, it is logged with the check decision
+var checkSignals = map[string]string{
+	CheckNameSpecialUse:   "special_use",
+	CheckNameAccessList:   "denied",
+	CheckNameDisposable:   "disposable",
+	CheckNameFreeProvider: "free_provider",
+	CheckNameBlackList:    "black_list",
+	CheckNameGibberish:    "gibberish",
+	CheckNameHomograph:    "homograph",
+	CheckNameTypo:         "typo",
+	CheckNameMX:           "mx_validation",
+}
+
+// Logger sets the logger of the validation, the check decisions and the DNS errors are logged at the debug level.
+// the local part of the address is redacted, unless it is changed with the LogLocalPart option
+func Logger(l *slog.Logger) OptionSetter {
+	return func(opt *Options) error {
+		opt.logger = l
+		return nil
+	}
+}
+
+// LogLocalPart sets how the local part of the address is logged
+func LogLocalPart(m LocalPart) OptionSetter {
+	return func(opt *Options) error {
+		opt.logLocalPart = m
+		return nil
+	}
+}
+
+// logAddress returns the address with the local part redacted, hashed or as is
+func (opt *Options) logAddress(address string) string {
+	i := strings.LastIndexByte(address, '@')
+	local, domain := address, ""
+	if i >= 0 {
+		local, domain = address[:i], address[i:]
+	}
+
+	switch opt.logLocalPart {
+	case LocalPartPlain:
+		return address
+	case LocalPartHash:
+		sum := sha256.Sum256([]byte(local))
+		return hex.EncodeToString(sum[:8]) + domain
+	}
+
+	return redactAddress(address)
+}
+
+// redactAddress returns the address with the local part replaced with "***"
+func redactAddress(address string) string {
+	if i := strings.LastIndexByte(address, '@'); i >= 0 {
+		return "***" + address[i:]
+	}
+
+	return "***"
+}
+
+// redactError returns the text of the error with the address in it (in any case) replaced with the redacted form,
+// the errors of the checks may have the address
+func redactError(err error, address, redacted string) string {
+	msg := err.Error()
+	if address == "" || address == redacted {
+		return msg
+	}
+
+	return regexp.MustCompile("(?i)"+regexp.QuoteMeta(address)).ReplaceAllLiteralString(msg, redacted)
+}
+
+// logError returns the text of the error with the address in it written like the logAddress
+func (opt *Options) logError(address string, err error) string {
+	return redactError(err, address, opt.logAddress(address))
+}
+
+// debug logs the message at the debug level, the attributes are not built if the level is disabled
+func (opt *Options) debug(ctx context.Context, msg string, attrs func() []slog.Attr) {
+	if opt.logger == nil || !opt.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	opt.logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs()...)
+}
+
+// logCheck logs the decision of the check, the error or the signal it set
+func (opt *Options) logCheck(ctx context.Context, in *Input, c Check, res *ValidationResult, err error) {
+	opt.debug(ctx, "email check", func() []slog.Attr {
+		attrs := []slog.Attr{
+			slog.String("address", opt.logAddress(in.Address)),
+			slog.String("check", c.Name()),
+			slog.String("phase", c.Phase().String()),
+		}
+		if err != nil {
+			return append(attrs, slog.String("decision", "rejected"), slog.String("error", opt.logError(in.Address, err)))
+		}
+
+		attrs = append(attrs, slog.String("decision", "passed"))
+		if name, ok := checkSignals[c.Name()]; ok {
+			s, _ := res.Signal(name)
+			attrs = append(attrs, slog.String(name, s.String()))
+		}
+		return attrs
+	})
+}
+
+// logValidation logs the outcome of the validation
+func (opt *Options) logValidation(ctx context.Context, address string, code string, err error) {
+	opt.debug(ctx, "email validation", func() []slog.Attr {
+		attrs := []slog.Attr{slog.String("address", opt.logAddress(address))}
+		if err != nil {
+			return append(attrs, slog.String("outcome", "invalid"), slog.String("code", code),
+				slog.String("error", opt.logError(address, err)))
+		}
+
+		return append(attrs, slog.String("outcome", "valid"))
+	})
+}
@@ -0,0 +1,127 @@
+package emailvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var res []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		m := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &m))
+		res = append(res, m)
+	}
+
+	return res
+}
+
+func TestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := Validate("johnsmith@gmail.com", Logger(l))
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "johnsmith")
+
+	lines := logLines(t, buf)
+	require.Len(t, lines, len(defaultChecks())+1)
+	assert.Equal(t, "email check", lines[0]["msg"])
+	assert.Equal(t, "***@gmail.com", lines[0]["address"])
+	assert.Equal(t, CheckNameLength, lines[0]["check"])
+	assert.Equal(t, "passed", lines[0]["decision"])
+	for _, line := range lines {
+		if line["check"] == CheckNameFreeProvider {
+			assert.Equal(t, "true", line["free_provider"])
+		}
+	}
+	last := lines[len(lines)-1]
+	assert.Equal(t, "email validation", last["msg"])
+	assert.Equal(t, "valid", last["outcome"])
+
+	buf.Reset()
+	_, err = Validate("johnsmith@gmail.invalidtld", Logger(l), LogLocalPart(LocalPartHash))
+	require.Error(t, err)
+	lines = logLines(t, buf)
+	last = lines[len(lines)-1]
+	assert.Equal(t, "invalid", last["outcome"])
+	assert.Equal(t, CheckNameTLD, last["code"])
+	assert.Regexp(t, "^[0-9a-f]{16}@gmail.invalidtld$", last["address"])
+	assert.Equal(t, "rejected", lines[len(lines)-2]["decision"])
+
+	buf.Reset()
+	_, err = Validate("johnsmith@gmail.com", Logger(l), LogLocalPart(LocalPartPlain))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"address":"johnsmith@gmail.com"`)
+
+	// nothing is logged above the debug level
+	buf.Reset()
+	_, err = Validate("johnsmith@gmail.com", Logger(slog.New(slog.NewJSONHandler(buf, nil))))
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestLoggerRedactError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	deny := []OptionSetter{Logger(l), DenyList(AccessList{Addresses: []string{"john.smith@gmail.com"}}), RejectDenied()}
+
+	_, err := Validate("John.Smith@gmail.com", deny...)
+	require.Error(t, err)
+	assert.NotContains(t, strings.ToLower(buf.String()), "john.smith")
+
+	lines := logLines(t, buf)
+	last := lines[len(lines)-1]
+	assert.Equal(t, "the address is in the deny list (address ***@gmail.com)", last["error"])
+	assert.Equal(t, last["error"], lines[len(lines)-2]["error"])
+
+	buf.Reset()
+	_, err = Validate("john.smith@gmail.com", append(deny, LogLocalPart(LocalPartHash))...)
+	require.Error(t, err)
+	lines = logLines(t, buf)
+	assert.Regexp(t, `^the address is in the deny list \(address [0-9a-f]{16}@gmail.com\)$`, lines[len(lines)-1]["error"])
+
+	buf.Reset()
+	_, err = Validate("john.smith@gmail.com", append(deny, LogLocalPart(LocalPartPlain))...)
+	require.Error(t, err)
+	lines = logLines(t, buf)
+	assert.Equal(t, err.Error(), lines[len(lines)-1]["error"])
+}
+
+func TestLoggerMX(t *testing.T) {
+	withResolver(t, &fakeResolver{})
+	buf := &bytes.Buffer{}
+	l := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	res, err := Validate("johnsmith@nothing.com", Logger(l), CheckMX(time.Second, false))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.MXValidation)
+
+	var found bool
+	for _, line := range logLines(t, buf) {
+		if line["msg"] == "email mx lookup failed" {
+			found = true
+			assert.Equal(t, "nothing.com", line["domain"])
+			assert.Equal(t, "nxdomain", line["status"])
+			assert.Contains(t, line["error"], "no such host")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestLogAddress(t *testing.T) {
+	opt := &Options{}
+	assert.Equal(t, "***@gmail.com", opt.logAddress("john@smith@gmail.com"))
+	assert.Equal(t, "***", opt.logAddress("invalid"))
+
+	opt.logLocalPart = LocalPartHash
+	assert.Equal(t, opt.logAddress("john@gmail.com"), opt.logAddress("john@yahoo.com")[:16]+"@gmail.com")
+	assert.NotEqual(t, opt.logAddress("john@gmail.com"), opt.logAddress("jane@gmail.com"))
+}
@@ -0,0 +1,144 @@
+// Package metrics is a Prometheus observer of the email validator, it is a prometheus.Collector too
+//
+//	m := metrics.NewObserver("")
+//	prometheus.MustRegister(m)
+//	res, err := emailvalidator.Validate(address, emailvalidator.Observe(m))
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/fzerorubigd/emailvalidator"
+)
+
+const defaultNamespace = "emailvalidator"
+
+type signal struct {
+	name    string
+	counter prometheus.Counter
+}
+
+// Observer counts the validations by the outcome and the error code, the signals, the cache lookups and the MX
+// lookups, it implements the emailvalidator.Observer
+type Observer struct {
+	validations *prometheus.CounterVec
+	signals     *prometheus.CounterVec
+	mxDuration  prometheus.Histogram
+	mxFailures  *prometheus.CounterVec
+	cache       *prometheus.CounterVec
+
+	valid    prometheus.Counter
+	hits     prometheus.Counter
+	misses   prometheus.Counter
+	observed []signal
+}
+
+// NewObserver creates the metrics, the default namespace is emailvalidator. the metrics are:
+//
+//	<namespace>_validations_total{outcome="valid|invalid", code}, the code is the error code of the invalid addresses
+//	<namespace>_signals_total{signal}, the signals (like disposable) that are true in the valid addresses
+//	<namespace>_mx_lookup_duration_seconds
+//	<namespace>_mx_lookup_failures_total{status}, the status is the emailvalidator.MXStatus of the error
+//	<namespace>_cache_requests_total{result="hit|miss"}
+func NewObserver(namespace string) *Observer {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	o := &Observer{
+		validations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validations_total",
+			Help:      "The number of the validations by the outcome and the error code.",
+		}, []string{"outcome", "code"}),
+		signals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signals_total",
+			Help:      "The number of the valid addresses with the signal.",
+		}, []string{"signal"}),
+		mxDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mx_lookup_duration_seconds",
+			Help:      "The duration of the MX lookups.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		}),
+		mxFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mx_lookup_failures_total",
+			Help:      "The number of the failed MX lookups by the status.",
+		}, []string{"status"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "The number of the result cache lookups by the result.",
+		}, []string{"result"}),
+	}
+
+	// the label values are resolved once, the validation path only increments the counters
+	o.valid = o.validations.WithLabelValues("valid", "")
+	o.hits = o.cache.WithLabelValues("hit")
+	o.misses = o.cache.WithLabelValues("miss")
+	for _, name := range emailvalidator.Signals() {
+		o.observed = append(o.observed, signal{name: name, counter: o.signals.WithLabelValues(name)})
+	}
+
+	return o
+}
+
+// ObserveValidation implements the emailvalidator.Observer
+func (o *Observer) ObserveValidation(res *emailvalidator.ValidationResult, code string, err error) {
+	if err != nil {
+		o.validations.WithLabelValues("invalid", code).Inc()
+		return
+	}
+
+	o.valid.Inc()
+	for i := range o.observed {
+		if s, _ := res.Signal(o.observed[i].name); s == emailvalidator.ValidationStateTrue {
+			o.observed[i].counter.Inc()
+		}
+	}
+}
+
+// ObserveMX implements the emailvalidator.Observer
+func (o *Observer) ObserveMX(d time.Duration, err error) {
+	o.mxDuration.Observe(d.Seconds())
+	if err != nil {
+		o.mxFailures.WithLabelValues(string(emailvalidator.ClassifyMXError(err))).Inc()
+	}
+}
+
+// ObserveCache implements the emailvalidator.Observer
+func (o *Observer) ObserveCache(hit bool) {
+	if hit {
+		o.hits.Inc()
+		return
+	}
+
+	o.misses.Inc()
+}
+
+// Describe implements the prometheus.Collector
+func (o *Observer) Describe(ch chan<- *prometheus.Desc) {
+	o.validations.Describe(ch)
+	o.signals.Describe(ch)
+	o.mxDuration.Describe(ch)
+	o.mxFailures.Describe(ch)
+	o.cache.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector
+func (o *Observer) Collect(ch chan<- prometheus.Metric) {
+	o.validations.Collect(ch)
+	o.signals.Collect(ch)
+	o.mxDuration.Collect(ch)
+	o.mxFailures.Collect(ch)
+	o.cache.Collect(ch)
+}
+
+var (
+	_ emailvalidator.Observer = (*Observer)(nil)
+	_ prometheus.Collector    = (*Observer)(nil)
+)
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fzerorubigd/emailvalidator"
+)
+
+func TestObserver(t *testing.T) {
+	m := NewObserver("")
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(m))
+
+	cache := emailvalidator.NewMemoryCache(10, 0)
+	for _, address := range []string{"johnsmith@gmail.com", "johnsmith@gmail.com", "info@mailinator.com", "invalid", "johnsmith@gmail.invalidtld"} {
+		_, _ = emailvalidator.Validate(address, emailvalidator.Observe(m), emailvalidator.ResultCache(cache))
+	}
+	m.ObserveMX(10*time.Millisecond, nil)
+	m.ObserveMX(20*time.Millisecond, errors.New("lookup failed"))
+
+	expected := `
+# HELP emailvalidator_cache_requests_total The number of the result cache lookups by the result.
+# TYPE emailvalidator_cache_requests_total counter
+emailvalidator_cache_requests_total{result="hit"} 1
+emailvalidator_cache_requests_total{result="miss"} 3
+# HELP emailvalidator_mx_lookup_failures_total The number of the failed MX lookups by the status.
+# TYPE emailvalidator_mx_lookup_failures_total counter
+emailvalidator_mx_lookup_failures_total{status="temporary"} 1
+# HELP emailvalidator_validations_total The number of the validations by the outcome and the error code.
+# TYPE emailvalidator_validations_total counter
+emailvalidator_validations_total{code="",outcome="valid"} 3
+emailvalidator_validations_total{code="syntax",outcome="invalid"} 1
+emailvalidator_validations_total{code="tld",outcome="invalid"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"emailvalidator_cache_requests_total", "emailvalidator_mx_lookup_failures_total", "emailvalidator_validations_total"))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.signals.WithLabelValues("free_provider")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.signals.WithLabelValues("disposable")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.signals.WithLabelValues("black_list")))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	var found bool
+	for _, f := range families {
+		if f.GetName() == "emailvalidator_mx_lookup_duration_seconds" {
+			found = true
+			assert.Equal(t, uint64(2), f.GetMetric()[0].GetHistogram().GetSampleCount())
+			assert.InDelta(t, 0.03, f.GetMetric()[0].GetHistogram().GetSampleSum(), 1e-9)
+		}
+	}
+	assert.True(t, found)
+}
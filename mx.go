@@ -0,0 +1,131 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// MXStatus is the outcome of the MX check
+type MXStatus string
+
+const (
+	// MXStatusOK means the domain has MX records, or an A/AAAA record when there is no MX record (RFC 5321)
+	MXStatusOK MXStatus = "ok"
+	// MXStatusNXDomain means the domain does not exist, and there is no zone for it up to the registrable domain. a
+	// missing subdomain of an existing domain is MXStatusNoRecords
+	MXStatusNXDomain MXStatus = "nxdomain"
+	// MXStatusNoRecords means the domain exists, but it has no MX and no A/AAAA record, or it has a null MX
+	// (RFC 7505) which means it does not accept email
+	MXStatusNoRecords MXStatus = "no_records"
+	// MXStatusTimeout means the lookup timed out
+	MXStatusTimeout MXStatus = "timeout"
+	// MXStatusServFail means the DNS server failed to answer (SERVFAIL or another error response)
+	MXStatusServFail MXStatus = "servfail"
+	// MXStatusTemporary is the other lookup errors, like a network error
+	MXStatusTemporary MXStatus = "temporary"
+)
+
+// Temporary returns true for the statuses that may change in a retry, the timeout, servfail and temporary
+func (s MXStatus) Temporary() bool {
+	return s == MXStatusTimeout || s == MXStatusServFail || s == MXStatusTemporary
+}
+
+// errNullMX is the error of a domain with a null MX record
+var errNullMX = errors.New("the domain has a null MX record")
+
+// MXLookupError is the error of the MX check, the Err is the error of the lookup (usually a *net.DNSError)
+type MXLookupError struct {
+	Status MXStatus
+	Domain string
+	Err    error
+}
+
+// Error implements the error
+func (e *MXLookupError) Error() string {
+	return "mx lookup of " + e.Domain + " failed (" + string(e.Status) + "): " + e.Err.Error()
+}
+
+// Unwrap returns the error of the lookup
+func (e *MXLookupError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyMXError returns the status of the MX lookup error, the status of a nil error is MXStatusOK
+func ClassifyMXError(err error) MXStatus {
+	if err == nil {
+		return MXStatusOK
+	}
+
+	var mxErr *MXLookupError
+	if errors.As(err, &mxErr) {
+		return mxErr.Status
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		switch {
+		case dnsErr.IsTimeout:
+			return MXStatusTimeout
+		case dnsErr.IsNotFound:
+			return MXStatusNXDomain
+		// the net package does not export the error, this is the text of the error responses like the SERVFAIL
+		case dnsErr.Err == "server misbehaving":
+			return MXStatusServFail
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return MXStatusTimeout
+	}
+
+	return MXStatusTemporary
+}
+
+func newMXLookupError(domain string, err error) error {
+	return &MXLookupError{Status: ClassifyMXError(err), Domain: domain, Err: err}
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// resolver is the DNS lookups used in the MX check
+type resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+var dnsResolver resolver = &net.Resolver{}
+
+// zoneExists tells if the domain or one of its parents up to the registrable domain has the NS records. the net
+// package does not tell the NXDOMAIN from a name without the records (NODATA), and a subdomain is usually not a zone
+// itself, so a name in an existing zone is not reported as NXDOMAIN
+func zoneExists(ctx context.Context, domain string) (bool, error) {
+	top, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		top = domain
+	}
+
+	for name := domain; ; name = name[strings.IndexByte(name, '.')+1:] {
+		ns, err := dnsResolver.LookupNS(ctx, name)
+		if err != nil && !isNotFound(err) {
+			return false, err
+		}
+		if len(ns) > 0 {
+			return true, nil
+		}
+		if name == top || strings.IndexByte(name, '.') < 0 {
+			return false, nil
+		}
+	}
+}
+
+func nullMX(records []*net.MX) bool {
+	return len(records) == 1 && (records[0].Host == "." || records[0].Host == "")
+}
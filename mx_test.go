@@ -0,0 +1,166 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver answers from the maps, a name that is not in a map is not found
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	ns    map[string][]*net.NS
+	err   map[string]error
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if err, ok := r.err[name]; ok {
+		return nil, err
+	}
+	if mx, ok := r.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if h, ok := r.hosts[host]; ok {
+		return h, nil
+	}
+	return nil, notFound(host)
+}
+
+func (r *fakeResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	if ns, ok := r.ns[name]; ok {
+		return ns, nil
+	}
+	return nil, notFound(name)
+}
+
+// nsErrorResolver fails the NS lookup of the name
+type nsErrorResolver struct {
+	*fakeResolver
+	name string
+}
+
+func (r *nsErrorResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	if name == r.name {
+		return nil, &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	}
+	return r.fakeResolver.LookupNS(ctx, name)
+}
+
+func withResolver(t *testing.T, r resolver) {
+	old := dnsResolver
+	dnsResolver = r
+	t.Cleanup(func() {
+		dnsResolver = old
+	})
+}
+
+func TestMXStatus(t *testing.T) {
+	withResolver(t, &fakeResolver{
+		mx: map[string][]*net.MX{
+			"mx.com":     {{Host: "mail.mx.com.", Pref: 10}},
+			"nullmx.com": {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{"host.com": {"192.0.2.1"}},
+		ns: map[string][]*net.NS{
+			"norecords.com": {{Host: "ns1.norecords.com."}},
+			"co.uk":         {{Host: "ns1.co.uk."}},
+		},
+		err: map[string]error{
+			"timeout.com":   &net.DNSError{Err: "i/o timeout", Name: "timeout.com", IsTimeout: true, IsTemporary: true},
+			"servfail.com":  &net.DNSError{Err: "server misbehaving", Name: "servfail.com", IsTemporary: true},
+			"temporary.com": &net.DNSError{Err: "connection refused", Name: "temporary.com"},
+			"deadline.com":  context.DeadlineExceeded,
+		},
+	})
+	// the NS lookup of the parent zone fails
+	withResolver(t, &nsErrorResolver{fakeResolver: dnsResolver.(*fakeResolver), name: "nsfail.com"})
+
+	tests := []struct {
+		domain string
+		status MXStatus
+	}{
+		{domain: "mx.com", status: MXStatusOK},
+		{domain: "host.com", status: MXStatusOK},
+		{domain: "nullmx.com", status: MXStatusNoRecords},
+		{domain: "norecords.com", status: MXStatusNoRecords},
+		{domain: "nxdomain.com", status: MXStatusNXDomain},
+		// a subdomain without the records and the NS records, in an existing zone
+		{domain: "mail.norecords.com", status: MXStatusNoRecords},
+		{domain: "a.b.norecords.com", status: MXStatusNoRecords},
+		{domain: "mail.nxdomain.com", status: MXStatusNXDomain},
+		// the public suffix is not the zone of the domain
+		{domain: "nxdomain.co.uk", status: MXStatusNXDomain},
+		{domain: "mail.nsfail.com", status: MXStatusServFail},
+		{domain: "timeout.com", status: MXStatusTimeout},
+		{domain: "servfail.com", status: MXStatusServFail},
+		{domain: "temporary.com", status: MXStatusTemporary},
+		{domain: "deadline.com", status: MXStatusTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			res, err := Validate("johnsmith@"+tt.domain, CheckMX(time.Second, false))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.MXStatus)
+			assert.Equal(t, tt.status.Temporary(), tt.status == MXStatusTimeout || tt.status == MXStatusServFail || tt.status == MXStatusTemporary)
+			if tt.status == MXStatusOK {
+				assert.Equal(t, ValidationStateTrue, res.MXValidation)
+				assert.NoError(t, res.MXError)
+				return
+			}
+
+			assert.Equal(t, ValidationStateFalse, res.MXValidation)
+			var mxErr *MXLookupError
+			require.True(t, errors.As(res.MXError, &mxErr))
+			assert.Equal(t, tt.domain, mxErr.Domain)
+			assert.Equal(t, tt.status, ClassifyMXError(res.MXError))
+		})
+	}
+
+	res, err := Validate("johnsmith@timeout.com", CheckMX(time.Second, false))
+	require.NoError(t, err)
+	var dnsErr *net.DNSError
+	require.True(t, errors.As(res.MXError, &dnsErr))
+	assert.True(t, dnsErr.IsTimeout)
+
+	res, err = Validate("johnsmith@mx.com")
+	require.NoError(t, err)
+	assert.Empty(t, res.MXStatus)
+}
+
+func TestMXStatusCache(t *testing.T) {
+	withResolver(t, &fakeResolver{
+		err: map[string]error{"timeout.com": &net.DNSError{Err: "i/o timeout", IsTimeout: true}},
+	})
+
+	c := NewMemoryCache(10, 0)
+	for _, domain := range []string{"timeout.com", "nxdomain.com"} {
+		_, err := Validate("johnsmith@"+domain, CheckMX(time.Second, false), ResultCache(c))
+		require.NoError(t, err)
+	}
+	// the temporary errors are not cached
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestClassifyMXError(t *testing.T) {
+	assert.Equal(t, MXStatusOK, ClassifyMXError(nil))
+	assert.Equal(t, MXStatusTemporary, ClassifyMXError(errors.New("unknown")))
+	assert.Equal(t, MXStatusNXDomain, ClassifyMXError(notFound("example.com")))
+	err := &MXLookupError{Status: MXStatusNoRecords, Domain: "example.com", Err: errNullMX}
+	assert.Equal(t, MXStatusNoRecords, ClassifyMXError(err))
+	assert.ErrorIs(t, err, errNullMX)
+	assert.Equal(t, "mx lookup of example.com failed (no_records): the domain has a null MX record", err.Error())
+}
@@ -0,0 +1,51 @@
+package emailvalidator
+
+import (
+	"context"
+	"time"
+)
+
+// CodeSyntax is the error code of the addresses that are not parsed, the other error codes are the name of the check
+// that rejected the address
+const CodeSyntax = "syntax"
+
+// Observer is notified of the validations, for example to export metrics. the methods are called in the validating
+// goroutine, so they should be fast and safe for concurrent use
+type Observer interface {
+	// ObserveValidation is called after each validation. for a valid address the res is the result and the code is
+	// empty, otherwise the code is the name of the check that rejected the address (or CodeSyntax) and the err is
+	// the error
+	ObserveValidation(res *ValidationResult, code string, err error)
+	// ObserveMX is called after each MX lookup, the shared lookups of a batch are called once
+	ObserveMX(d time.Duration, err error)
+	// ObserveCache is called on each lookup in the result cache
+	ObserveCache(hit bool)
+}
+
+// Observe sets the observer of the validations, without an observer there is no overhead
+func Observe(o Observer) OptionSetter {
+	return func(opt *Options) error {
+		opt.observer = o
+		return nil
+	}
+}
+
+func (opt *Options) observeValidation(res *ValidationResult, code string, err error) {
+	if opt.observer != nil {
+		opt.observer.ObserveValidation(res, code, err)
+	}
+}
+
+// observeMX wraps the MX lookup to report its duration to the observer
+func observeMX(lookup func(context.Context, string) error, o Observer) func(context.Context, string) error {
+	if o == nil {
+		return lookup
+	}
+
+	return func(ctx context.Context, domain string) error {
+		start := time.Now()
+		err := lookup(ctx, domain)
+		o.ObserveMX(time.Since(start), err)
+		return err
+	}
+}
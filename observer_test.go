@@ -0,0 +1,58 @@
+package emailvalidator
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	lock  sync.Mutex
+	codes []string
+	mx    int
+	hits  []bool
+}
+
+func (r *recorder) ObserveValidation(_ *ValidationResult, code string, _ error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.codes = append(r.codes, code)
+}
+
+func (r *recorder) ObserveMX(time.Duration, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.mx++
+}
+
+func (r *recorder) ObserveCache(hit bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hits = append(r.hits, hit)
+}
+
+func TestObserver(t *testing.T) {
+	r := &recorder{}
+	c := NewMemoryCache(10, 0)
+	for _, address := range []string{"johnsmith@gmail.com", "johnsmith@gmail.com", "invalid", "johnsmith@gmail.invalidtld"} {
+		_, _ = Validate(address, Observe(r), ResultCache(c))
+	}
+	assert.Equal(t, []string{"", "", CodeSyntax, CheckNameTLD}, r.codes)
+	assert.Equal(t, []bool{false, true, false}, r.hits)
+	assert.Equal(t, 0, r.mx)
+
+	// the lookups of a batch are shared, so the domain is looked up once
+	withResolver(t, &fakeResolver{mx: map[string][]*net.MX{"example.org": {{Host: "mail.example.org", Pref: 10}}}})
+	r = &recorder{}
+	res := ValidateBatch(context.Background(), func(yield func(string) bool) {
+		_ = yield("johnsmith@example.org") && yield("janesmith@example.org")
+	}, Observe(r), CheckMX(time.Millisecond, false), AllowSpecialUse())
+	require.Len(t, res, 2)
+	assert.Equal(t, 1, r.mx)
+	assert.Len(t, r.codes, 2)
+}
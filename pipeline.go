@@ -0,0 +1,327 @@
+package emailvalidator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+var defaultValidator = NewValidator()
+
+// Validator is a validation pipeline, an ordered list of checks. the built-in checks can be reordered or disabled
+// and custom checks can be registered. it is safe for concurrent use.
+type Validator struct {
+	lock     sync.RWMutex
+	checks   []Check
+	disabled map[string]bool
+	opts     []OptionSetter
+}
+
+// NewValidator creates a validator with the built-in checks, the options are applied before the options passed to
+// each ValidateContext call
+func NewValidator(opts ...OptionSetter) *Validator {
+	return &Validator{
+		checks:   defaultChecks(),
+		disabled: make(map[string]bool),
+		opts:     opts,
+	}
+}
+
+func (v *Validator) index(name string) int {
+	for i := range v.checks {
+		if v.checks[i].Name() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (v *Validator) insert(i int, c Check) error {
+	if v.index(c.Name()) >= 0 {
+		return fmt.Errorf("check %q is already registered", c.Name())
+	}
+
+	v.checks = append(v.checks, nil)
+	copy(v.checks[i+1:], v.checks[i:])
+	v.checks[i] = c
+	return nil
+}
+
+// Register adds the check at the end of its phase
+func (v *Validator) Register(c Check) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	i := len(v.checks)
+	for i > 0 && v.checks[i-1].Phase() > c.Phase() {
+		i--
+	}
+
+	return v.insert(i, c)
+}
+
+// RegisterBefore adds the check just before the named check
+func (v *Validator) RegisterBefore(name string, c Check) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	i := v.index(name)
+	if i < 0 {
+		return fmt.Errorf("check %q not found", name)
+	}
+
+	return v.insert(i, c)
+}
+
+// RegisterAfter adds the check just after the named check
+func (v *Validator) RegisterAfter(name string, c Check) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	i := v.index(name)
+	if i < 0 {
+		return fmt.Errorf("check %q not found", name)
+	}
+
+	return v.insert(i+1, c)
+}
+
+// Remove removes the check from the pipeline
+func (v *Validator) Remove(name string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	i := v.index(name)
+	if i < 0 {
+		return fmt.Errorf("check %q not found", name)
+	}
+
+	v.checks = append(v.checks[:i], v.checks[i+1:]...)
+	delete(v.disabled, name)
+	return nil
+}
+
+// Disable keeps the check in the pipeline, but skips it until it is enabled again
+func (v *Validator) Disable(name string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.index(name) < 0 {
+		return fmt.Errorf("check %q not found", name)
+	}
+
+	v.disabled[name] = true
+	return nil
+}
+
+// Enable enables a disabled check
+func (v *Validator) Enable(name string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.index(name) < 0 {
+		return fmt.Errorf("check %q not found", name)
+	}
+
+	delete(v.disabled, name)
+	return nil
+}
+
+// Reorder changes the order of the named checks, they take the positions they already have in the pipeline, in
+// the order given. the other checks are not moved. for example in a pipeline "a, b, c, d" calling Reorder("d", "b")
+// results in "a, d, c, b"
+func (v *Validator) Reorder(names ...string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	var (
+		pos    []int
+		checks []Check
+		seen   = make(map[string]bool, len(names))
+	)
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("check %q is repeated", name)
+		}
+		seen[name] = true
+
+		i := v.index(name)
+		if i < 0 {
+			return fmt.Errorf("check %q not found", name)
+		}
+		pos = append(pos, i)
+		checks = append(checks, v.checks[i])
+	}
+
+	sort.Ints(pos)
+	for i := range pos {
+		v.checks[pos[i]] = checks[i]
+	}
+
+	return nil
+}
+
+// Checks returns the name of the checks in the pipeline, in order. disabled checks are included
+func (v *Validator) Checks() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	names := make([]string, 0, len(v.checks))
+	for i := range v.checks {
+		names = append(names, v.checks[i].Name())
+	}
+
+	return names
+}
+
+func (v *Validator) pipeline() []Check {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	checks := make([]Check, 0, len(v.checks))
+	for i := range v.checks {
+		if !v.disabled[v.checks[i].Name()] {
+			checks = append(checks, v.checks[i])
+		}
+	}
+
+	return checks
+}
+
+// ValidateContext runs the pipeline on the address, the context is passed to each check
+func (v *Validator) ValidateContext(ctx context.Context, address string, opts ...OptionSetter) (*ValidationResult, error) {
+	res, _, err := v.validateContext(ctx, address, opts)
+	return res, err
+}
+
+// validateContext is the ValidateContext that also returns the parsed address
+func (v *Validator) validateContext(ctx context.Context, address string, opts []OptionSetter) (*ValidationResult, *Input, error) {
+	opt := &Options{}
+	for _, set := range [][]OptionSetter{v.opts, opts} {
+		for i := range set {
+			if err := set[i](opt); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if opt.tracer == nil && trace.SpanFromContext(ctx).IsRecording() {
+		opt.tracer = tracer
+	}
+	ctx, span := opt.startSpan(ctx, "Validate")
+	if opt.traceAddress && span.IsRecording() {
+		span.SetAttributes(AttributeAddress.String(address))
+	}
+
+	res, in, code, err := v.validate(ctx, address, opt)
+	opt.observeValidation(res, code, err)
+	opt.logValidation(ctx, address, code, err)
+	opt.endValidation(span, address, code, err)
+
+	return res, in, err
+}
+
+// validate runs the pipeline, the code is the name of the check that returned the error
+func (v *Validator) validate(ctx context.Context, address string, opt *Options) (*ValidationResult, *Input, string, error) {
+	_, parse := opt.startSpan(ctx, "parse")
+	username, domain, tld, err := extractEmailParts(address)
+	opt.endSpan(parse, address, err)
+	if err != nil {
+		return nil, nil, CodeSyntax, err
+	}
+
+	// the domain names are case insensitive
+	in := &Input{
+		Address:  address,
+		UserName: username,
+		Domain:   strings.ToLower(domain),
+		TLD:      strings.ToLower(tld),
+		opt:      opt,
+	}
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetAttributes(AttributeDomain.String(in.Domain))
+	}
+
+	checks := v.pipeline()
+	var key string
+	if opt.cache != nil {
+		cacheCtx, span := opt.startSpan(ctx, "cache")
+		key = cacheKey(in, checks)
+		e, ok, err := opt.cache.Get(cacheCtx, key)
+		hit := err == nil && ok && e.Version == cacheVersion() && e.Result != nil
+		if span.IsRecording() {
+			span.SetAttributes(AttributeCacheHit.Bool(hit))
+		}
+		opt.endSpan(span, address, err)
+		if opt.observer != nil {
+			opt.observer.ObserveCache(hit)
+		}
+		opt.debug(ctx, "email cache", func() []slog.Attr {
+			return []slog.Attr{slog.String("address", opt.logAddress(address)), slog.Bool("hit", hit)}
+		})
+		if hit {
+			return e.Result.clone(), in, "", nil
+		}
+	}
+
+	res := ValidationResult{}
+	if code, err := runChecks(ctx, in, checks, &res); err != nil {
+		return nil, in, code, err
+	}
+
+	// a canceled context or a temporary DNS error may change the result of the network checks, so it is not cached
+	if opt.cache != nil && ctx.Err() == nil && !res.MXStatus.Temporary() {
+		_ = opt.cache.Set(ctx, key, CacheEntry{Version: cacheVersion(), Result: res.clone()})
+	}
+
+	return &res, in, "", nil
+}
+
+// runChecks runs the checks in a span for each phase, the checks in the network phase have their own span too. the
+// decision of each check is logged. it returns the name of the check that returned an error
+func runChecks(ctx context.Context, in *Input, checks []Check, res *ValidationResult) (string, error) {
+	var (
+		phaseCtx context.Context
+		phase    trace.Span
+	)
+	for i, c := range checks {
+		if i == 0 || c.Phase() != checks[i-1].Phase() {
+			if phase != nil {
+				phase.End()
+			}
+			phaseCtx, phase = in.opt.startSpan(ctx, c.Phase().String())
+		}
+
+		checkCtx := phaseCtx
+		var span trace.Span
+		if c.Phase() == PhaseNetwork {
+			checkCtx, span = in.opt.startSpan(phaseCtx, "check."+c.Name())
+		}
+
+		err := c.Check(checkCtx, in, res)
+		in.opt.logCheck(ctx, in, c, res, err)
+		if span != nil {
+			in.opt.endSpan(span, in.Address, err)
+		}
+		if err != nil {
+			in.opt.endSpan(phase, in.Address, err)
+			return c.Name(), err
+		}
+	}
+	if phase != nil {
+		phase.End()
+	}
+
+	return "", nil
+}
+
+// Validate runs the pipeline on the address
+func (v *Validator) Validate(address string, opts ...OptionSetter) (*ValidationResult, error) {
+	return v.ValidateContext(context.Background(), address, opts...)
+}
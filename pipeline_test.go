@@ -0,0 +1,135 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorChecks(t *testing.T) {
+	v := NewValidator()
+	assert.Equal(t, []string{
+		CheckNameLength,
+		CheckNameAccessList,
+		CheckNameSpecialUse,
+		CheckNameTLD,
+		CheckNameUserName,
+		CheckNameDisposable,
+		CheckNameFreeProvider,
+		CheckNameBlackList,
+		CheckNameGibberish,
+		CheckNameHomograph,
+		CheckNameTypo,
+		CheckNameMX,
+	}, v.Checks())
+
+	banned := NewCheck("banned", PhaseData, func(_ context.Context, in *Input, _ *ValidationResult) error {
+		if in.Domain == "banned.com" {
+			return errors.New("banned")
+		}
+		return nil
+	})
+	require.NoError(t, v.Register(banned))
+	require.Error(t, v.Register(banned))
+	assert.Equal(t, []string{
+		CheckNameLength,
+		CheckNameAccessList,
+		CheckNameSpecialUse,
+		CheckNameTLD,
+		CheckNameUserName,
+		CheckNameDisposable,
+		CheckNameFreeProvider,
+		CheckNameBlackList,
+		CheckNameGibberish,
+		CheckNameHomograph,
+		CheckNameTypo,
+		"banned",
+		CheckNameMX,
+	}, v.Checks())
+
+	_, err := v.Validate("test@banned.com")
+	require.Error(t, err)
+	_, err = Validate("test@banned.com")
+	require.NoError(t, err)
+
+	require.NoError(t, v.Disable("banned"))
+	_, err = v.Validate("test@banned.com")
+	require.NoError(t, err)
+	require.NoError(t, v.Enable("banned"))
+	require.NoError(t, v.Remove("banned"))
+	_, err = v.Validate("test@banned.com")
+	require.NoError(t, err)
+	require.Error(t, v.Remove("banned"))
+	require.Error(t, v.Disable("banned"))
+
+	require.NoError(t, v.Reorder(CheckNameBlackList, CheckNameDisposable))
+	assert.Equal(t, []string{
+		CheckNameLength,
+		CheckNameAccessList,
+		CheckNameSpecialUse,
+		CheckNameTLD,
+		CheckNameUserName,
+		CheckNameBlackList,
+		CheckNameFreeProvider,
+		CheckNameDisposable,
+		CheckNameGibberish,
+		CheckNameHomograph,
+		CheckNameTypo,
+		CheckNameMX,
+	}, v.Checks())
+	require.Error(t, v.Reorder(CheckNameMX, CheckNameMX))
+	require.Error(t, v.Reorder("not-exists"))
+
+	var order []string
+	first := NewCheck("first", PhaseSyntax, func(_ context.Context, _ *Input, _ *ValidationResult) error {
+		order = append(order, "first")
+		return nil
+	})
+	last := NewCheck("last", PhaseSyntax, func(_ context.Context, _ *Input, _ *ValidationResult) error {
+		order = append(order, "last")
+		return nil
+	})
+	require.NoError(t, v.RegisterBefore(CheckNameLength, first))
+	require.NoError(t, v.RegisterAfter(CheckNameMX, last))
+	require.Error(t, v.RegisterAfter("not-exists", NewCheck("x", PhaseData, nil)))
+	_, err = v.Validate("test@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "last"}, order)
+	assert.Equal(t, "first", v.Checks()[0])
+	assert.Equal(t, "last", v.Checks()[len(v.Checks())-1])
+}
+
+func TestValidatorDisableBuiltIn(t *testing.T) {
+	v := NewValidator()
+	require.NoError(t, v.Disable(CheckNameDisposable))
+	require.NoError(t, v.Disable(CheckNameTLD))
+	require.NoError(t, v.Disable(CheckNameSpecialUse))
+
+	res, err := v.Validate("test@things.10mail.invalidtld")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateNotChecked, res.Disposable)
+	assert.Equal(t, ValidationStateFalse, res.FreeProvider)
+	assert.Equal(t, ValidationStateFalse, res.BlackList)
+}
+
+func TestValidatorDomainCase(t *testing.T) {
+	var domain, tld string
+	v := NewValidator()
+	require.NoError(t, v.Register(NewCheck("case", PhaseData, func(_ context.Context, in *Input, _ *ValidationResult) error {
+		domain, tld = in.Domain, in.TLD
+		return nil
+	})))
+
+	res, err := v.Validate("JohnSmith@GMail.COM")
+	require.NoError(t, err)
+	assert.Equal(t, "gmail.com", domain)
+	assert.Equal(t, "com", tld)
+	assert.Equal(t, ValidationStateTrue, res.FreeProvider)
+
+	res, err = v.Validate("info@MAILINATOR.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.Disposable)
+}
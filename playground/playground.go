@@ -0,0 +1,167 @@
+// Package playground registers the checks of the emailvalidator as the tags of the go-playground/validator, so the
+// struct fields can be validated with the embedded data instead of a regular expression:
+//
+//	type Signup struct {
+//		Email string `validate:"email_strict,not_disposable,not_role"`
+//	}
+//
+//	v := validator.New()
+//	if err := playground.Register(v, nil); err != nil {
+//		...
+//	}
+//
+// each tag validates the address, with a context from WithMemo the tags of a StructCtx or VarCtx call share one
+// validation of each address:
+//
+//	err := v.StructCtx(playground.WithMemo(ctx), signup)
+package playground
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/fzerorubigd/emailvalidator"
+)
+
+// TagEmailStrict is the tag for a valid address, it fails for the addresses that the validator rejects
+const TagEmailStrict = "email_strict"
+
+// aliases are the short tags for the common signals
+var aliases = map[string]string{
+	"not_free": "free_provider",
+	"not_role": "black_list",
+}
+
+// positive are the signals that are desirable when they are true, they have no not_<signal> tag
+var positive = map[string]bool{
+	"mx_validation": true,
+}
+
+// signalTag returns the tag of a signal, the address is valid and the signal is not true
+func signalTag(signal string) string {
+	return "not_" + signal
+}
+
+type memoKey struct{}
+
+type memoEntry struct {
+	once sync.Once
+	res  *emailvalidator.ValidationResult
+	err  error
+}
+
+// memo is the results of the validations in a call, by the checker and the address
+type memo struct {
+	lock    sync.Mutex
+	entries map[memoID]*memoEntry
+}
+
+type memoID struct {
+	checker *checker
+	address string
+}
+
+// WithMemo returns a context that keeps the validation results, pass it to the StructCtx or VarCtx of the validator
+// so the tags validate each address once. the results are kept as long as the context, use a new one for each call
+func WithMemo(ctx context.Context) context.Context {
+	return context.WithValue(ctx, memoKey{}, &memo{entries: make(map[memoID]*memoEntry)})
+}
+
+type checker struct {
+	validator *emailvalidator.Validator
+	opts      []emailvalidator.OptionSetter
+}
+
+// result validates the address, or returns the result of the validation in the memo of the context
+func (c *checker) result(ctx context.Context, address string) (*emailvalidator.ValidationResult, error) {
+	m, ok := ctx.Value(memoKey{}).(*memo)
+	if !ok {
+		return c.validator.ValidateContext(ctx, address, c.opts...)
+	}
+
+	id := memoID{checker: c, address: address}
+	m.lock.Lock()
+	e, ok := m.entries[id]
+	if !ok {
+		e = &memoEntry{}
+		m.entries[id] = e
+	}
+	m.lock.Unlock()
+
+	e.once.Do(func() {
+		e.res, e.err = c.validator.ValidateContext(ctx, address, c.opts...)
+	})
+	return e.res, e.err
+}
+
+func (c *checker) validate(ctx context.Context, fl validator.FieldLevel) (*emailvalidator.ValidationResult, bool) {
+	var address string
+	switch v := fl.Field().Interface().(type) {
+	case string:
+		address = v
+	case emailvalidator.Address:
+		address = v.String()
+	default:
+		return nil, false
+	}
+
+	res, err := c.result(ctx, address)
+	if err != nil {
+		return nil, false
+	}
+
+	return res, true
+}
+
+func (c *checker) strict(ctx context.Context, fl validator.FieldLevel) bool {
+	_, ok := c.validate(ctx, fl)
+	return ok
+}
+
+func (c *checker) not(signal string) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		res, ok := c.validate(ctx, fl)
+		if !ok {
+			return false
+		}
+
+		state, _ := res.Signal(signal)
+		return state != emailvalidator.ValidationStateTrue
+	}
+}
+
+// Register installs the tags on the validator: email_strict for a valid address, and not_<signal> for each signal
+// in emailvalidator.Signals() that is undesirable when it is true (like not_disposable and not_free_provider, there
+// is no not_mx_validation), with the not_free and not_role as the short forms of the not_free_provider and
+// not_black_list. the signal tags fail for the invalid addresses too. the fields can be a string or an
+// emailvalidator.Address. the checks use the ev, or a new validator if it is nil, and the options are passed to each
+// validation. each tag validates the address, unless the context is from WithMemo
+func Register(v *validator.Validate, ev *emailvalidator.Validator, opts ...emailvalidator.OptionSetter) error {
+	if ev == nil {
+		ev = emailvalidator.NewValidator()
+	}
+	c := &checker{validator: ev, opts: opts}
+
+	if err := v.RegisterValidationCtx(TagEmailStrict, c.strict); err != nil {
+		return err
+	}
+
+	for _, signal := range emailvalidator.Signals() {
+		if positive[signal] {
+			continue
+		}
+		if err := v.RegisterValidationCtx(signalTag(signal), c.not(signal)); err != nil {
+			return err
+		}
+	}
+
+	for tag, signal := range aliases {
+		if err := v.RegisterValidationCtx(tag, c.not(signal)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package playground
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fzerorubigd/emailvalidator"
+)
+
+type signup struct {
+	Email   string                 `validate:"email_strict,not_disposable,not_role"`
+	Work    string                 `validate:"omitempty,not_free"`
+	Address emailvalidator.Address `validate:"omitempty,not_disposable"`
+}
+
+func failedTags(err error) []string {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	var res []string
+	for _, e := range errs {
+		res = append(res, e.Field()+":"+e.Tag())
+	}
+	return res
+}
+
+func TestRegister(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, Register(v, nil))
+
+	assert.NoError(t, v.Struct(signup{Email: "john.smith@gmail.com"}))
+	assert.Equal(t, []string{"Email:email_strict"}, failedTags(v.Struct(signup{Email: "invalid"})))
+	assert.Equal(t, []string{"Email:email_strict"}, failedTags(v.Struct(signup{})))
+	assert.Equal(t, []string{"Email:not_disposable"}, failedTags(v.Struct(signup{Email: "john.smith@mailinator.com"})))
+	assert.Equal(t, []string{"Email:not_role"}, failedTags(v.Struct(signup{Email: "noreply@company.com"})))
+	assert.Equal(t, []string{"Work:not_free"}, failedTags(v.Struct(signup{Email: "john.smith@company.com", Work: "john.smith@gmail.com"})))
+
+	a, err := emailvalidator.ParseAddress("john.smith@mailinator.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Address:not_disposable"}, failedTags(v.Struct(signup{Email: "john.smith@company.com", Address: a})))
+
+	assert.NoError(t, v.Var("john.smith@gmail.com", "not_homograph,not_gibberish"))
+	assert.Error(t, v.Var(12, "email_strict"))
+}
+
+func TestRegisterOptions(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, Register(v, nil, emailvalidator.DenyList(emailvalidator.AccessList{Domains: []string{"company.com"}})))
+
+	assert.NoError(t, v.Var("john.smith@company.com", "email_strict"))
+	assert.Error(t, v.Var("john.smith@company.com", "not_denied"))
+	assert.NoError(t, v.Var("john.smith@gmail.com", "not_denied"))
+
+	ev := emailvalidator.NewValidator()
+	require.NoError(t, ev.Register(emailvalidator.NewCheck("no_test", emailvalidator.PhaseData, func(_ context.Context, in *emailvalidator.Input, _ *emailvalidator.ValidationResult) error {
+		if in.UserName == "test" {
+			return errors.New("test user")
+		}
+		return nil
+	})))
+
+	v = validator.New()
+	require.NoError(t, Register(v, ev))
+	assert.Error(t, v.Var("test@gmail.com", "email_strict"))
+	assert.NoError(t, v.Var("john.smith@gmail.com", "email_strict"))
+}
+
+type counter struct {
+	validations atomic.Int64
+}
+
+func (c *counter) ObserveValidation(*emailvalidator.ValidationResult, string, error) {
+	c.validations.Add(1)
+}
+func (c *counter) ObserveMX(time.Duration, error) {}
+func (c *counter) ObserveCache(bool)              {}
+
+func TestRegisterMemo(t *testing.T) {
+	c := &counter{}
+	v := validator.New()
+	require.NoError(t, Register(v, nil, emailvalidator.Observe(c)))
+	ctx := context.Background()
+
+	// without the memo each tag validates the address
+	assert.NoError(t, v.Var("john.doe@gmail.com", "email_strict,not_disposable,not_role"))
+	assert.Equal(t, int64(3), c.validations.Load())
+	assert.NoError(t, v.Var("john.doe@gmail.com", "email_strict"))
+	assert.Equal(t, int64(4), c.validations.Load())
+
+	c.validations.Store(0)
+	assert.NoError(t, v.StructCtx(WithMemo(ctx), signup{Email: "john.smith@gmail.com"}))
+	assert.Equal(t, int64(1), c.validations.Load())
+
+	assert.NoError(t, v.VarCtx(WithMemo(ctx), "john.doe@gmail.com", "email_strict,not_disposable,not_role,not_typo"))
+	assert.Equal(t, int64(2), c.validations.Load())
+
+	// the memo is not shared between the calls
+	assert.NoError(t, v.VarCtx(WithMemo(ctx), "john.doe@gmail.com", "email_strict"))
+	assert.Equal(t, int64(3), c.validations.Load())
+
+	assert.Error(t, v.VarCtx(WithMemo(ctx), "invalid", "email_strict,not_disposable"))
+	assert.Equal(t, int64(4), c.validations.Load())
+
+	// the validators with other options do not share the results
+	deny := validator.New()
+	require.NoError(t, Register(deny, nil, emailvalidator.DenyList(emailvalidator.AccessList{Domains: []string{"gmail.com"}})))
+	memo := WithMemo(ctx)
+	assert.NoError(t, v.VarCtx(memo, "john.doe@gmail.com", "not_denied"))
+	assert.Error(t, deny.VarCtx(memo, "john.doe@gmail.com", "not_denied"))
+}
+
+func TestRegisterMemoConcurrent(t *testing.T) {
+	c := &counter{}
+	v := validator.New()
+	require.NoError(t, Register(v, nil, emailvalidator.Observe(c)))
+
+	const calls = 50
+	var wg sync.WaitGroup
+	errs := make([]error, calls)
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("john.smith%d@gmail.com", i)
+			if i%2 == 1 {
+				email = fmt.Sprintf("john.smith%d@mailinator.com", i)
+			}
+			errs[i] = v.StructCtx(WithMemo(context.Background()), signup{Email: email})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if i%2 == 1 {
+			assert.Equal(t, []string{"Email:not_disposable"}, failedTags(err), i)
+		} else {
+			assert.NoError(t, err, i)
+		}
+	}
+	assert.Equal(t, int64(calls), c.validations.Load())
+}
+
+func TestRegisterPositive(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, Register(v, nil))
+
+	assert.Panics(t, func() { _ = v.Var("john.smith@gmail.com", "not_mx_validation") })
+}
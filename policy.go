@@ -0,0 +1,147 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Action is the decision of a policy about an address
+type Action int
+
+const (
+	// ActionAccept means the address is accepted
+	ActionAccept Action = iota
+	// ActionWarn means the address is accepted, but with a warning
+	ActionWarn
+	// ActionReject means the address should be rejected
+	ActionReject
+)
+
+var actionNames = map[Action]string{
+	ActionAccept: "accept",
+	ActionWarn:   "warn",
+	ActionReject: "reject",
+}
+
+// String returns the name of the action
+func (a Action) String() string {
+	if s, ok := actionNames[a]; ok {
+		return s
+	}
+
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// MarshalJSON json transform for the value
+func (a Action) MarshalJSON() ([]byte, error) {
+	s, ok := actionNames[a]
+	if !ok {
+		return nil, fmt.Errorf("action %d not supported", a)
+	}
+
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON json transform for the value
+func (a *Action) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	for k, v := range actionNames {
+		if v == s {
+			*a = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("action %q not supported", s)
+}
+
+// Rule maps a signal to an action. the rule matches when the signal is true, or when it is false if the Negate is
+// set. a signal which is not checked never matches
+type Rule struct {
+	Signal string `json:"signal"`
+	Negate bool   `json:"negate,omitempty"`
+	Action Action `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Policy is a set of rules, the strictest action of all matching rules is the decision
+type Policy struct {
+	Name  string `json:"name,omitempty"`
+	Rules []Rule `json:"rules"`
+}
+
+// Reason is a matched rule in a decision
+type Reason struct {
+	Signal string `json:"signal"`
+	Action Action `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Decision is the result of evaluating a policy
+type Decision struct {
+	Action  Action   `json:"action"`
+	Reasons []Reason `json:"reasons,omitempty"`
+}
+
+// ParsePolicy creates a policy from its json representation
+func ParsePolicy(data []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Validate checks the rules for unknown signals and actions
+func (p *Policy) Validate() error {
+	for i := range p.Rules {
+		if _, ok := signals[p.Rules[i].Signal]; !ok {
+			return fmt.Errorf("rule %d: signal %q not supported", i, p.Rules[i].Signal)
+		}
+		if _, ok := actionNames[p.Rules[i].Action]; !ok {
+			return fmt.Errorf("rule %d: action %d not supported", i, p.Rules[i].Action)
+		}
+	}
+
+	return nil
+}
+
+// Evaluate applies the policy on the validation result. rules with an unknown signal are ignored, use Validate to
+// catch them
+func (p *Policy) Evaluate(res *ValidationResult) *Decision {
+	d := &Decision{Action: ActionAccept}
+	for _, r := range p.Rules {
+		fn, ok := signals[r.Signal]
+		if !ok {
+			continue
+		}
+
+		want := ValidationStateTrue
+		if r.Negate {
+			want = ValidationStateFalse
+		}
+		if fn(res) != want {
+			continue
+		}
+
+		d.Reasons = append(d.Reasons, Reason{
+			Signal: r.Signal,
+			Action: r.Action,
+			Reason: r.Reason,
+		})
+		if r.Action > d.Action {
+			d.Action = r.Action
+		}
+	}
+
+	return d
+}
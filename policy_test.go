@@ -0,0 +1,58 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	p, err := ParsePolicy([]byte(`{
+		"name": "signup",
+		"rules": [
+			{"signal": "disposable", "action": "reject", "reason": "disposable address"},
+			{"signal": "black_list", "action": "warn", "reason": "role account"},
+			{"signal": "mx_validation", "negate": true, "action": "reject", "reason": "no mail server"}
+		]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "signup", p.Name)
+
+	d := p.Evaluate(&ValidationResult{
+		Disposable: ValidationStateFalse,
+		BlackList:  ValidationStateFalse,
+	})
+	assert.Equal(t, ActionAccept, d.Action)
+	assert.Empty(t, d.Reasons)
+
+	d = p.Evaluate(&ValidationResult{
+		Disposable: ValidationStateFalse,
+		BlackList:  ValidationStateTrue,
+	})
+	assert.Equal(t, ActionWarn, d.Action)
+	assert.Equal(t, []Reason{{Signal: "black_list", Action: ActionWarn, Reason: "role account"}}, d.Reasons)
+
+	d = p.Evaluate(&ValidationResult{
+		Disposable:   ValidationStateTrue,
+		BlackList:    ValidationStateTrue,
+		MXValidation: ValidationStateFalse,
+	})
+	assert.Equal(t, ActionReject, d.Action)
+	assert.Len(t, d.Reasons, 3)
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(b, &m))
+	assert.Equal(t, "reject", m["action"])
+
+	_, err = ParsePolicy([]byte(`{"rules": [{"signal": "unknown", "action": "reject"}]}`))
+	require.Error(t, err)
+	_, err = ParsePolicy([]byte(`{"rules": [{"signal": "disposable", "action": "drop"}]}`))
+	require.Error(t, err)
+	require.Error(t, (&Policy{Rules: []Rule{{Signal: "disposable", Action: 10}}}).Validate())
+	assert.Equal(t, "warn", ActionWarn.String())
+	assert.Equal(t, "Action(10)", Action(10).String())
+}
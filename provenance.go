@@ -0,0 +1,56 @@
+package emailvalidator
+
+import "strings"
+
+// provenance is the bit mask of the index of the sources of a domain in the dataSources
+type provenance uint32
+
+// Name of the lists in the DataProvenance
+const (
+	DataListDisposable         = "disposable"
+	DataListWildcardDisposable = "wildcard_disposable"
+	DataListFreeProvider       = "free_provider"
+)
+
+// DataProvenance is the reason a domain is in one of the embedded lists. the Entry is the entry in the list that
+// matched the domain, it is the parent domain for the wildcard list. the Sources are the upstream sources that
+// include the entry, "override" means the entry is added by the local override files in the generator
+type DataProvenance struct {
+	List    string   `json:"list"`
+	Entry   string   `json:"entry"`
+	Sources []string `json:"sources"`
+}
+
+func (p provenance) sources() []string {
+	var res []string
+	for i := range dataSources {
+		if p&(1<<uint(i)) != 0 {
+			res = append(res, dataSources[i])
+		}
+	}
+
+	return res
+}
+
+// Provenance returns the embedded lists that include the domain and their sources, it answers why a domain is
+// flagged as disposable or free provider
+func Provenance(domain string) []DataProvenance {
+	domain = strings.ToLower(domain)
+
+	var res []DataProvenance
+	parts := strings.Split(domain, ".")
+	if len(parts) > 2 {
+		entry := strings.Join(parts[len(parts)-2:], ".")
+		if p := wildDisposableDomain.provenance(entry); p != 0 {
+			res = append(res, DataProvenance{List: DataListWildcardDisposable, Entry: entry, Sources: p.sources()})
+		}
+	} else if p := disposableDomain.provenance(domain); p != 0 {
+		res = append(res, DataProvenance{List: DataListDisposable, Entry: domain, Sources: p.sources()})
+	}
+
+	if p := freeProvider.provenance(domain); p != 0 {
+		res = append(res, DataProvenance{List: DataListFreeProvider, Entry: domain, Sources: p.sources()})
+	}
+
+	return res
+}
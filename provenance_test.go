@@ -0,0 +1,36 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenance(t *testing.T) {
+	assert.Equal(t, []DataProvenance{{
+		List:    DataListDisposable,
+		Entry:   "bcaoo.com",
+		Sources: []string{"ivolo/disposable-email-domains"},
+	}}, Provenance("BCAOO.com"))
+
+	assert.Equal(t, []DataProvenance{{
+		List:    DataListWildcardDisposable,
+		Entry:   "10mail.org",
+		Sources: []string{"ivolo/disposable-email-domains"},
+	}}, Provenance("things.more.10mail.org"))
+
+	assert.Equal(t, []DataProvenance{{
+		List:    DataListFreeProvider,
+		Entry:   "gmail.com",
+		Sources: []string{"daveearley/Email-Validation-Tool"},
+	}}, Provenance("gmail.com"))
+
+	assert.Equal(t, []DataProvenance{{
+		List:    DataListFreeProvider,
+		Entry:   "proton.me",
+		Sources: []string{"override"},
+	}}, Provenance("proton.me"))
+
+	assert.Empty(t, Provenance("example.com"))
+	assert.Equal(t, []string{"daveearley/Email-Validation-Tool", "override"}, provenance(6).sources())
+}
@@ -0,0 +1,83 @@
+// Package ratelimit is a per client token bucket rate limiter. one limiter can be shared by the HTTP and the gRPC
+// services, so a client has the same budget on both of them.
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket of one client
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter allows each client rate tokens per second, with bursts of up to burst tokens. the idle clients are removed
+// on the way. it is safe for concurrent use
+type Limiter struct {
+	lock    sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// New creates a limiter with the rate tokens per second and the burst
+func New(rate float64, burst int) (*Limiter, error) {
+	if rate <= 0 || burst < 1 {
+		return nil, errors.New("invalid rate limit")
+	}
+
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}, nil
+}
+
+// Burst returns the burst of the limiter, the largest number of tokens that can be taken at once
+func (l *Limiter) Burst() int {
+	return int(l.burst)
+}
+
+// AllowN takes n tokens from the bucket of the client at the time now, if there is not enough tokens it returns false
+// and the time to wait for them. n larger than the burst is never allowed
+func (l *Limiter) AllowN(client string, now time.Time, n int) (bool, time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= float64(n) {
+		b.tokens -= float64(n)
+		return true, 0
+	}
+
+	wait := (float64(n) - b.tokens) / l.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// sweep removes the clients with a full bucket, at most once a minute
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
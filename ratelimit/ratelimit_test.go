@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	l, err := New(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, l.Burst())
+
+	now := time.Now()
+	ok, _ := l.AllowN("a", now, 2)
+	assert.True(t, ok)
+	ok, wait := l.AllowN("a", now, 1)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+	ok, _ = l.AllowN("b", now, 1)
+	assert.True(t, ok)
+
+	ok, _ = l.AllowN("a", now.Add(time.Second), 1)
+	assert.True(t, ok)
+	ok, _ = l.AllowN("a", now.Add(time.Hour), 3)
+	assert.False(t, ok)
+
+	_, err = New(0, 1)
+	assert.Error(t, err)
+	_, err = New(1, 0)
+	assert.Error(t, err)
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l, err := New(1, 1)
+	require.NoError(t, err)
+	now := time.Now()
+
+	ok, _ := l.AllowN("a", now, 1)
+	assert.True(t, ok)
+	assert.Len(t, l.buckets, 1)
+
+	now = now.Add(2 * time.Minute)
+	ok, _ = l.AllowN("b", now, 1)
+	assert.True(t, ok)
+	assert.Len(t, l.buckets, 1)
+}
@@ -0,0 +1,117 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"math"
+)
+
+// RiskWeight is the weight of a signal in the risk score. the weight is added to the score when the signal is
+// true, or when it is false if the Negate is set
+type RiskWeight struct {
+	Signal string  `json:"signal"`
+	Negate bool    `json:"negate,omitempty"`
+	Weight float64 `json:"weight"`
+}
+
+// DefaultRiskWeights is the weights used in the DefaultRiskScorer. there is no catch-all signal, detecting a
+// catch-all domain needs an SMTP conversation with its mail server, which this package does not do
+var DefaultRiskWeights = []RiskWeight{
+	{Signal: "denied", Weight: 100},
+	{Signal: "special_use", Weight: 80},
+	{Signal: "disposable", Weight: 70},
+	{Signal: "homograph", Weight: 70},
+	{Signal: "mx_validation", Negate: true, Weight: 50},
+	{Signal: "gibberish", Weight: 40},
+	{Signal: "mixed_script", Weight: 30},
+	{Signal: "typo", Weight: 30},
+	{Signal: "black_list", Weight: 20},
+	{Signal: "free_provider", Weight: 10},
+}
+
+// RiskContribution is the share of a signal in a risk score
+type RiskContribution struct {
+	Signal string  `json:"signal"`
+	Negate bool    `json:"negate,omitempty"`
+	Weight float64 `json:"weight"`
+	Points float64 `json:"points"`
+}
+
+// RiskScore is the risk of an address, from 0 (no risk) to 100. the Contributions is the share of each signal in
+// the Value, the signals with no contribution are not included
+type RiskScore struct {
+	Value         int                `json:"value"`
+	Contributions []RiskContribution `json:"contributions,omitempty"`
+}
+
+// RiskScorer calculates the risk score of a validation result
+type RiskScorer struct {
+	weights []RiskWeight
+}
+
+// NewRiskScorer creates a scorer with the weights, the weights should be between 0 and 100
+func NewRiskScorer(weights ...RiskWeight) (*RiskScorer, error) {
+	for i := range weights {
+		if _, ok := signals[weights[i].Signal]; !ok {
+			return nil, fmt.Errorf("signal %q not supported", weights[i].Signal)
+		}
+
+		if weights[i].Weight < 0 || weights[i].Weight > 100 || math.IsNaN(weights[i].Weight) {
+			return nil, fmt.Errorf("weight %f for signal %q is out of range", weights[i].Weight, weights[i].Signal)
+		}
+	}
+
+	return &RiskScorer{
+		weights: append([]RiskWeight(nil), weights...),
+	}, nil
+}
+
+// DefaultRiskScorer returns a scorer with the DefaultRiskWeights
+func DefaultRiskScorer() *RiskScorer {
+	s, err := NewRiskScorer(DefaultRiskWeights...)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Score calculates the risk score. the weights of the matching signals are added, if the total is more than 100
+// the score is 100 and each contribution is scaled down by the same ratio
+func (s *RiskScorer) Score(res *ValidationResult) *RiskScore {
+	var (
+		total float64
+		score = &RiskScore{}
+	)
+	for _, w := range s.weights {
+		want := ValidationStateTrue
+		if w.Negate {
+			want = ValidationStateFalse
+		}
+		if signals[w.Signal](res) != want || w.Weight == 0 {
+			continue
+		}
+
+		total += w.Weight
+		score.Contributions = append(score.Contributions, RiskContribution{
+			Signal: w.Signal,
+			Negate: w.Negate,
+			Weight: w.Weight,
+			Points: w.Weight,
+		})
+	}
+
+	if total > 100 {
+		for i := range score.Contributions {
+			score.Contributions[i].Points = score.Contributions[i].Points * 100 / total
+		}
+		total = 100
+	}
+	score.Value = int(math.Round(total))
+
+	return score
+}
+
+// Explain returns a copy of the Contributions
+func (r *RiskScore) Explain() []RiskContribution {
+	return append([]RiskContribution(nil), r.Contributions...)
+}
@@ -0,0 +1,51 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiskScore(t *testing.T) {
+	s := DefaultRiskScorer()
+
+	r := s.Score(&ValidationResult{
+		Disposable:   ValidationStateFalse,
+		FreeProvider: ValidationStateTrue,
+		BlackList:    ValidationStateFalse,
+	})
+	assert.Equal(t, 10, r.Value)
+	assert.Equal(t, []RiskContribution{{Signal: "free_provider", Weight: 10, Points: 10}}, r.Explain())
+
+	r = s.Score(&ValidationResult{
+		Disposable:   ValidationStateTrue,
+		MXValidation: ValidationStateFalse,
+	})
+	assert.Equal(t, 100, r.Value)
+	require.Len(t, r.Explain(), 2)
+	assert.InDelta(t, 58.33, r.Explain()[0].Points, 0.01)
+	assert.InDelta(t, 41.67, r.Explain()[1].Points, 0.01)
+	assert.True(t, r.Explain()[1].Negate)
+
+	b, err := json.Marshal(s.Score(&ValidationResult{FreeProvider: ValidationStateTrue, Typo: ValidationStateTrue}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"value": 40, "contributions": [
+		{"signal": "typo", "weight": 30, "points": 30},
+		{"signal": "free_provider", "weight": 10, "points": 10}
+	]}`, string(b))
+
+	r = s.Score(&ValidationResult{})
+	assert.Equal(t, 0, r.Value)
+	assert.Empty(t, r.Explain())
+
+	s, err = NewRiskScorer(RiskWeight{Signal: "black_list", Weight: 35})
+	require.NoError(t, err)
+	assert.Equal(t, 35, s.Score(&ValidationResult{BlackList: ValidationStateTrue}).Value)
+
+	_, err = NewRiskScorer(RiskWeight{Signal: "unknown", Weight: 35})
+	require.Error(t, err)
+	_, err = NewRiskScorer(RiskWeight{Signal: "black_list", Weight: 101})
+	require.Error(t, err)
+}
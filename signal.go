@@ -0,0 +1,40 @@
+package emailvalidator
+
+import "sort"
+
+// signals maps the signal name to the state in the result, the name is the json name of the field in the
+// ValidationResult. policies and risk scores refer to the signals by these names
+var signals = map[string]func(*ValidationResult) ValidationState{
+	"free_provider": func(r *ValidationResult) ValidationState { return r.FreeProvider },
+	"disposable":    func(r *ValidationResult) ValidationState { return r.Disposable },
+	"mx_validation": func(r *ValidationResult) ValidationState { return r.MXValidation },
+	"black_list":    func(r *ValidationResult) ValidationState { return r.BlackList },
+	"gibberish":     func(r *ValidationResult) ValidationState { return r.Gibberish },
+	"mixed_script":  func(r *ValidationResult) ValidationState { return r.MixedScript },
+	"homograph":     func(r *ValidationResult) ValidationState { return r.Homograph },
+	"typo":          func(r *ValidationResult) ValidationState { return r.Typo },
+	"denied":        func(r *ValidationResult) ValidationState { return r.Denied },
+	"special_use":   func(r *ValidationResult) ValidationState { return r.SpecialUse },
+}
+
+// Signals returns the name of all signals supported in policies
+func Signals() []string {
+	names := make([]string, 0, len(signals))
+	for name := range signals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Signal returns the state of the named signal in the result, the second return value is false if the signal is not
+// supported
+func (r *ValidationResult) Signal(name string) (ValidationState, bool) {
+	fn, ok := signals[name]
+	if !ok {
+		return ValidationStateNotChecked, false
+	}
+
+	return fn(r), true
+}
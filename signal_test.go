@@ -0,0 +1,31 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignal(t *testing.T) {
+	res := &ValidationResult{Disposable: ValidationStateTrue, FreeProvider: ValidationStateFalse}
+
+	s, ok := res.Signal("disposable")
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStateTrue, s)
+
+	s, ok = res.Signal("free_provider")
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStateFalse, s)
+
+	s, ok = res.Signal("mx_validation")
+	assert.True(t, ok)
+	assert.Equal(t, ValidationStateNotChecked, s)
+
+	_, ok = res.Signal("unknown")
+	assert.False(t, ok)
+
+	for _, name := range Signals() {
+		_, ok := res.Signal(name)
+		assert.True(t, ok, name)
+	}
+}
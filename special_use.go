@@ -0,0 +1,121 @@
+package emailvalidator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SpecialUseKind is the kind of a special-use domain
+type SpecialUseKind string
+
+// Kind of the special-use domains
+const (
+	// SpecialUseReservedTLD is for the reserved top level domains in RFC 2606, RFC 6761 and RFC 9476, like .test
+	SpecialUseReservedTLD SpecialUseKind = "reserved_tld"
+	// SpecialUseExample is for the example second level domains in RFC 2606, like example.com
+	SpecialUseExample SpecialUseKind = "example"
+	// SpecialUseOnion is for the Tor hidden services in RFC 7686
+	SpecialUseOnion SpecialUseKind = "onion"
+	// SpecialUseLocal is for the multicast DNS names in RFC 6762
+	SpecialUseLocal SpecialUseKind = "local"
+	// SpecialUseHomeArpa is for the home networks in RFC 8375
+	SpecialUseHomeArpa SpecialUseKind = "home_arpa"
+	// SpecialUseInternal is for the private use top level domain reserved by ICANN
+	SpecialUseInternal SpecialUseKind = "internal"
+)
+
+// specialUse is the special-use domains registry, each domain includes all of its sub domains
+var specialUse = map[string]SpecialUseKind{
+	"test":        SpecialUseReservedTLD,
+	"invalid":     SpecialUseReservedTLD,
+	"localhost":   SpecialUseReservedTLD,
+	"example":     SpecialUseReservedTLD,
+	"alt":         SpecialUseReservedTLD,
+	"example.com": SpecialUseExample,
+	"example.net": SpecialUseExample,
+	"example.org": SpecialUseExample,
+	"onion":       SpecialUseOnion,
+	"local":       SpecialUseLocal,
+	"home.arpa":   SpecialUseHomeArpa,
+	"internal":    SpecialUseInternal,
+}
+
+// rejectedSpecialUse is the default, the special-use top level domains that are not in the root zone are rejected
+var rejectedSpecialUse = map[SpecialUseKind]bool{
+	SpecialUseReservedTLD: true,
+	SpecialUseOnion:       true,
+	SpecialUseLocal:       true,
+	SpecialUseInternal:    true,
+}
+
+// RejectSpecialUse rejects the addresses in the special-use domains of the kinds, or all of them if there is no
+// kind. by default the reserved, onion, local and internal domains are rejected
+func RejectSpecialUse(kinds ...SpecialUseKind) OptionSetter {
+	return setSpecialUse(true, kinds)
+}
+
+// AllowSpecialUse accepts the addresses in the special-use domains of the kinds, or all of them if there is no kind.
+// the SpecialUse is still true in the result
+func AllowSpecialUse(kinds ...SpecialUseKind) OptionSetter {
+	return setSpecialUse(false, kinds)
+}
+
+func setSpecialUse(reject bool, kinds []SpecialUseKind) OptionSetter {
+	return func(opt *Options) error {
+		if len(kinds) == 0 {
+			kinds = []SpecialUseKind{
+				SpecialUseReservedTLD,
+				SpecialUseExample,
+				SpecialUseOnion,
+				SpecialUseLocal,
+				SpecialUseHomeArpa,
+				SpecialUseInternal,
+			}
+		}
+
+		if opt.specialUse == nil {
+			opt.specialUse = make(map[SpecialUseKind]bool)
+		}
+		for _, k := range kinds {
+			opt.specialUse[k] = reject
+		}
+		return nil
+	}
+}
+
+// SpecialUse returns the kind of the special-use domain, or an empty string if the domain is not special
+func SpecialUse(domain string) SpecialUseKind {
+	domain = strings.ToLower(domain)
+	for {
+		if k, ok := specialUse[domain]; ok {
+			return k
+		}
+
+		i := strings.Index(domain, ".")
+		if i < 0 {
+			return ""
+		}
+		domain = domain[i+1:]
+	}
+}
+
+func checkSpecialUse(_ context.Context, in *Input, res *ValidationResult) error {
+	res.SpecialUse = ValidationStateFalse
+	kind := SpecialUse(in.Domain)
+	if kind == "" {
+		return nil
+	}
+
+	reject, ok := in.opt.specialUse[kind]
+	if !ok {
+		reject = rejectedSpecialUse[kind]
+	}
+	if reject {
+		return fmt.Errorf("the %s is a special-use domain (%s)", in.Domain, kind)
+	}
+
+	res.SpecialUse = ValidationStateTrue
+	res.SpecialUseKind = kind
+	return nil
+}
@@ -0,0 +1,51 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecialUse(t *testing.T) {
+	assert.Equal(t, SpecialUseExample, SpecialUse("Example.com"))
+	assert.Equal(t, SpecialUseExample, SpecialUse("mail.example.org"))
+	assert.Equal(t, SpecialUseReservedTLD, SpecialUse("foo.localhost"))
+	assert.Equal(t, SpecialUseReservedTLD, SpecialUse("test.invalid"))
+	assert.Equal(t, SpecialUseOnion, SpecialUse("abc.onion"))
+	assert.Equal(t, SpecialUseLocal, SpecialUse("printer.local"))
+	assert.Equal(t, SpecialUseHomeArpa, SpecialUse("router.home.arpa"))
+	assert.Equal(t, SpecialUseInternal, SpecialUse("corp.internal"))
+	assert.Equal(t, SpecialUseKind(""), SpecialUse("example.co"))
+	assert.Equal(t, SpecialUseKind(""), SpecialUse("myexample.com"))
+	assert.Equal(t, SpecialUseKind(""), SpecialUse("in-addr.arpa"))
+
+	res, err := Validate("john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.SpecialUse)
+	assert.Equal(t, SpecialUseExample, res.SpecialUseKind)
+
+	res, err = Validate("johnsmith@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.SpecialUse)
+	assert.Empty(t, res.SpecialUseKind)
+
+	_, err = Validate("john@example.com", RejectSpecialUse(SpecialUseExample))
+	require.Error(t, err)
+	_, err = Validate("john@router.home.arpa", RejectSpecialUse())
+	require.Error(t, err)
+
+	for _, email := range []string{"john@test.invalid", "john@foo.localhost", "john@abc.onion", "john@corp.internal"} {
+		_, err = Validate(email)
+		require.Error(t, err, email)
+	}
+
+	res, err = Validate("john@abc.onion", AllowSpecialUse(SpecialUseOnion))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateTrue, res.SpecialUse)
+	assert.Equal(t, SpecialUseOnion, res.SpecialUseKind)
+
+	res, err = Validate("john@foo.test", AllowSpecialUse())
+	require.NoError(t, err)
+	assert.Equal(t, SpecialUseReservedTLD, res.SpecialUseKind)
+}
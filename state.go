@@ -0,0 +1,170 @@
+package emailvalidator
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// String returns the name of the state, not_checked, true or false
+func (v ValidationState) String() string {
+	switch v {
+	case ValidationStateNotChecked:
+		return "not_checked"
+	case ValidationStateFalse:
+		return "false"
+	case ValidationStateTrue:
+		return "true"
+	}
+
+	return "ValidationState(" + strconv.Itoa(int(v)) + ")"
+}
+
+// MarshalJSON json transform for the value
+func (v ValidationState) MarshalJSON() ([]byte, error) {
+	switch v {
+	case ValidationStateNotChecked:
+		return []byte("null"), nil
+	case ValidationStateFalse:
+		return []byte("false"), nil
+	case ValidationStateTrue:
+		return []byte("true"), nil
+	}
+
+	return nil, fmt.Errorf("value %d not supported", v)
+}
+
+// UnmarshalJSON json transform for the value, null is the not checked state
+func (v *ValidationState) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "null":
+		*v = ValidationStateNotChecked
+	case "false":
+		*v = ValidationStateFalse
+	case "true":
+		*v = ValidationStateTrue
+	default:
+		return fmt.Errorf("invalid validation state %s", b)
+	}
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler, the not checked state is an empty text
+func (v ValidationState) MarshalText() ([]byte, error) {
+	switch v {
+	case ValidationStateNotChecked:
+		return []byte{}, nil
+	case ValidationStateFalse:
+		return []byte("false"), nil
+	case ValidationStateTrue:
+		return []byte("true"), nil
+	}
+
+	return nil, fmt.Errorf("value %d not supported", v)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler, an empty text is the not checked state and the rest are
+// parsed with strconv.ParseBool, so the t and f of the postgres text format are accepted too
+func (v *ValidationState) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*v = ValidationStateNotChecked
+		return nil
+	}
+
+	ok, err := strconv.ParseBool(string(b))
+	if err != nil {
+		return fmt.Errorf("invalid validation state %q", b)
+	}
+
+	*v = stateOf(ok)
+	return nil
+}
+
+// Scan implements the sql.Scanner, NULL is the not checked state. the integers 0 and 1 are false and true, as the
+// booleans stored by the Value in the databases without a boolean type. use the LegacyValidationState for the rows
+// that stored the numeric value of the state
+func (v *ValidationState) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*v = ValidationStateNotChecked
+		return nil
+	case bool:
+		*v = stateOf(s)
+		return nil
+	case int64:
+		if s == 0 || s == 1 {
+			*v = stateOf(s == 1)
+			return nil
+		}
+		return fmt.Errorf("invalid validation state %d", s)
+	case string:
+		return v.UnmarshalText([]byte(s))
+	case []byte:
+		return v.UnmarshalText(s)
+	}
+
+	return fmt.Errorf("can not scan %T into a ValidationState", src)
+}
+
+// Value implements the driver.Valuer, the not checked state is NULL and the rest are booleans
+func (v ValidationState) Value() (driver.Value, error) {
+	switch v {
+	case ValidationStateNotChecked:
+		return nil, nil
+	case ValidationStateFalse:
+		return false, nil
+	case ValidationStateTrue:
+		return true, nil
+	}
+
+	return nil, fmt.Errorf("value %d not supported", v)
+}
+
+// LegacyValidationState is a ValidationState stored as its numeric value, 0 not checked, 1 true and 2 false. it is
+// for the columns that were written with the number of the state, the ValidationState itself stores a boolean
+type LegacyValidationState ValidationState
+
+// Scan implements the sql.Scanner, NULL is the not checked state and the integers (or their text) are the numeric
+// value of the state
+func (v *LegacyValidationState) Scan(src interface{}) error {
+	var n int64
+	switch s := src.(type) {
+	case nil:
+	case int64:
+		n = s
+	case string, []byte:
+		var err error
+		if n, err = strconv.ParseInt(fmt.Sprintf("%s", s), 10, 64); err != nil {
+			return fmt.Errorf("invalid validation state %q", s)
+		}
+	default:
+		return fmt.Errorf("can not scan %T into a LegacyValidationState", src)
+	}
+
+	switch state := ValidationState(n); state {
+	case ValidationStateNotChecked, ValidationStateTrue, ValidationStateFalse:
+		*v = LegacyValidationState(state)
+		return nil
+	}
+
+	return fmt.Errorf("invalid validation state %d", n)
+}
+
+// Value implements the driver.Valuer, the state is stored as its numeric value
+func (v LegacyValidationState) Value() (driver.Value, error) {
+	switch state := ValidationState(v); state {
+	case ValidationStateNotChecked, ValidationStateTrue, ValidationStateFalse:
+		return int64(state), nil
+	}
+
+	return nil, fmt.Errorf("value %d not supported", v)
+}
+
+func stateOf(b bool) ValidationState {
+	if b {
+		return ValidationStateTrue
+	}
+
+	return ValidationStateFalse
+}
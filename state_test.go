@@ -0,0 +1,156 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var allStates = []ValidationState{ValidationStateNotChecked, ValidationStateTrue, ValidationStateFalse}
+
+func TestValidationStateString(t *testing.T) {
+	assert.Equal(t, "not_checked", ValidationStateNotChecked.String())
+	assert.Equal(t, "true", ValidationStateTrue.String())
+	assert.Equal(t, "false", ValidationStateFalse.String())
+	assert.Equal(t, "ValidationState(-1)", ValidationState(-1).String())
+}
+
+func TestValidationStateJSON(t *testing.T) {
+	for _, s := range allStates {
+		b, err := json.Marshal(s)
+		require.NoError(t, err)
+
+		got := ValidationState(-1)
+		require.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, s, got, s.String())
+	}
+
+	for _, in := range []string{`1`, `"true"`, `{}`, `nil`} {
+		var s ValidationState
+		assert.Error(t, json.Unmarshal([]byte(in), &s), in)
+	}
+
+	_, err := json.Marshal(ValidationState(3))
+	assert.Error(t, err)
+}
+
+func TestValidationStateText(t *testing.T) {
+	for _, s := range allStates {
+		b, err := s.MarshalText()
+		require.NoError(t, err)
+
+		got := ValidationState(-1)
+		require.NoError(t, got.UnmarshalText(b))
+		assert.Equal(t, s, got, s.String())
+	}
+
+	var s ValidationState
+	require.NoError(t, s.UnmarshalText([]byte("t")))
+	assert.Equal(t, ValidationStateTrue, s)
+	assert.Error(t, s.UnmarshalText([]byte("maybe")))
+
+	_, err := ValidationState(3).MarshalText()
+	assert.Error(t, err)
+}
+
+func TestValidationStateSQL(t *testing.T) {
+	for _, s := range allStates {
+		v, err := s.Value()
+		require.NoError(t, err)
+
+		got := ValidationState(-1)
+		require.NoError(t, got.Scan(v))
+		assert.Equal(t, s, got, s.String())
+	}
+
+	tests := []struct {
+		src  interface{}
+		want ValidationState
+	}{
+		{src: true, want: ValidationStateTrue},
+		{src: false, want: ValidationStateFalse},
+		{src: int64(1), want: ValidationStateTrue},
+		{src: int64(0), want: ValidationStateFalse},
+		{src: "0", want: ValidationStateFalse},
+		{src: []byte("1"), want: ValidationStateTrue},
+		{src: "f", want: ValidationStateFalse},
+		{src: []byte("true"), want: ValidationStateTrue},
+		{src: []byte{}, want: ValidationStateNotChecked},
+	}
+	for _, tt := range tests {
+		var s ValidationState
+		require.NoError(t, s.Scan(tt.src))
+		assert.Equal(t, tt.want, s)
+	}
+
+	var s ValidationState
+	assert.Error(t, s.Scan(int64(2)))
+	assert.Error(t, s.Scan(int64(-1)))
+	assert.Error(t, s.Scan("2"))
+	assert.Error(t, s.Scan(1.5))
+	assert.Error(t, s.Scan("maybe"))
+
+	_, err := ValidationState(3).Value()
+	assert.Error(t, err)
+}
+
+func TestValidationStateSQLRoundTrip(t *testing.T) {
+	// the databases without a boolean type store the booleans of the Value as 0 and 1
+	for _, s := range allStates {
+		v, err := s.Value()
+		require.NoError(t, err)
+
+		var src interface{}
+		if b, ok := v.(bool); ok {
+			src = int64(0)
+			if b {
+				src = int64(1)
+			}
+		}
+
+		got := ValidationState(-1)
+		require.NoError(t, got.Scan(src))
+		assert.Equal(t, s, got, s.String())
+	}
+}
+
+func TestLegacyValidationState(t *testing.T) {
+	for _, s := range allStates {
+		v, err := LegacyValidationState(s).Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(s), v)
+
+		for _, src := range []interface{}{int64(s), strconv.Itoa(int(s)), []byte(strconv.Itoa(int(s)))} {
+			got := LegacyValidationState(-1)
+			require.NoError(t, got.Scan(src))
+			assert.Equal(t, s, ValidationState(got), "%v", src)
+		}
+	}
+
+	var s LegacyValidationState
+	require.NoError(t, s.Scan(int64(2)))
+	assert.Equal(t, ValidationStateFalse, ValidationState(s))
+	require.NoError(t, s.Scan(nil))
+	assert.Equal(t, ValidationStateNotChecked, ValidationState(s))
+
+	assert.Error(t, s.Scan(int64(3)))
+	assert.Error(t, s.Scan("true"))
+	assert.Error(t, s.Scan(true))
+	_, err := LegacyValidationState(3).Value()
+	assert.Error(t, err)
+}
+
+func TestValidationResultRoundTrip(t *testing.T) {
+	res, err := Validate("johnsmith@gmail.com")
+	require.NoError(t, err)
+
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	var got ValidationResult
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, *res, got)
+}
@@ -0,0 +1,45 @@
+package emailvalidator
+
+import "strings"
+
+// TLDType is the type of a top level domain, based on the IANA root zone database
+type TLDType string
+
+// Type of the top level domains
+const (
+	TLDGeneric        TLDType = "generic"
+	TLDCountryCode    TLDType = "country-code"
+	TLDSponsored      TLDType = "sponsored"
+	TLDBrand          TLDType = "brand"
+	TLDInfrastructure TLDType = "infrastructure"
+	TLDTest           TLDType = "test"
+)
+
+// TLDInfo is the metadata of a top level domain. the Country is the ISO 3166 code for the country code top level
+// domains, and the Unicode is the Unicode form of the IDN top level domains (the Name is always the ASCII form). the
+// Sponsor and the sponsored, brand and test types are only known if the data was generated with the root zone
+// database and the brand list
+type TLDInfo struct {
+	Name    string  `json:"name"`
+	Type    TLDType `json:"type"`
+	Sponsor string  `json:"sponsor,omitempty"`
+	Country string  `json:"country,omitempty"`
+	Unicode string  `json:"unicode,omitempty"`
+}
+
+// LookupTLD returns the metadata of the top level domain, the second return value is false if the tld is not valid
+func LookupTLD(tld string) (TLDInfo, bool) {
+	tld = strings.ToLower(tld)
+	values, ok := tlds.lookup(tld)
+	if !ok {
+		return TLDInfo{}, false
+	}
+
+	info := TLDInfo{Name: tld}
+	var typ string
+	typ, values, _ = strings.Cut(values, "\t")
+	info.Type = TLDType(typ)
+	info.Sponsor, values, _ = strings.Cut(values, "\t")
+	info.Country, info.Unicode, _ = strings.Cut(values, "\t")
+	return info, true
+}
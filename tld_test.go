@@ -0,0 +1,39 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupTLD(t *testing.T) {
+	info, ok := LookupTLD("COM")
+	require.True(t, ok)
+	assert.Equal(t, "com", info.Name)
+	assert.Equal(t, TLDGeneric, info.Type)
+	assert.Empty(t, info.Country)
+
+	info, ok = LookupTLD("uk")
+	require.True(t, ok)
+	assert.Equal(t, TLDCountryCode, info.Type)
+	assert.Equal(t, "GB", info.Country)
+
+	info, ok = LookupTLD("xn--p1ai")
+	require.True(t, ok)
+	assert.Equal(t, TLDCountryCode, info.Type)
+	assert.Equal(t, "RU", info.Country)
+	assert.Equal(t, "рф", info.Unicode)
+
+	info, ok = LookupTLD("arpa")
+	require.True(t, ok)
+	assert.Equal(t, TLDInfrastructure, info.Type)
+
+	_, ok = LookupTLD("invalidtld")
+	require.False(t, ok)
+
+	res, err := Validate("john@example.de")
+	require.NoError(t, err)
+	require.NotNil(t, res.TLD)
+	assert.Equal(t, "DE", res.TLD.Country)
+}
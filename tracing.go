@@ -0,0 +1,90 @@
+package emailvalidator
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/fzerorubigd/emailvalidator"
+
+var (
+	// tracer is the tracer of the global provider, it follows the provider set later with otel.SetTracerProvider
+	tracer = otel.Tracer(tracerName)
+	// noopSpan is used when the validation is not traced
+	noopSpan = trace.SpanFromContext(context.Background())
+)
+
+// The attributes of the validation spans
+const (
+	AttributeDomain   = attribute.Key("emailvalidator.domain")
+	AttributeAddress  = attribute.Key("emailvalidator.address")
+	AttributeOutcome  = attribute.Key("emailvalidator.outcome")
+	AttributeCode     = attribute.Key("emailvalidator.code")
+	AttributeCacheHit = attribute.Key("emailvalidator.cache_hit")
+	AttributeMXValid  = attribute.Key("emailvalidator.mx_valid")
+	AttributeMXStatus = attribute.Key("emailvalidator.mx_status")
+)
+
+// TracerProvider sets the provider of the validation spans. without it the validation is traced with the global
+// provider only if the context has a recording span, so there is no overhead when the caller is not traced. each
+// validation is a span with a child span for the parsing, the cache lookup and each phase of the checks. the checks
+// in the network phase (like the MX lookup) have their own span
+func TracerProvider(tp trace.TracerProvider) OptionSetter {
+	t := tp.Tracer(tracerName)
+	return func(opt *Options) error {
+		opt.tracer = t
+		return nil
+	}
+}
+
+// TraceAddress adds the full address to the validation spans, by default only the domain is added
+func TraceAddress() OptionSetter {
+	return func(opt *Options) error {
+		opt.traceAddress = true
+		return nil
+	}
+}
+
+// startSpan starts a child span, or returns a no-op span if the validation is not traced
+func (opt *Options) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	if opt.tracer == nil {
+		return ctx, noopSpan
+	}
+
+	return opt.tracer.Start(ctx, "emailvalidator."+name)
+}
+
+// endSpan records the error in the span and ends it, the address in the error is redacted unless the TraceAddress is
+// set
+func (opt *Options) endSpan(span trace.Span, address string, err error) {
+	if err != nil && span.IsRecording() {
+		if !opt.traceAddress {
+			if msg := redactError(err, address, redactAddress(address)); msg != err.Error() {
+				err = errors.New(msg)
+			}
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// endValidation sets the outcome of the validation in the span and ends it
+func (opt *Options) endValidation(span trace.Span, address, code string, err error) {
+	if !span.IsRecording() {
+		span.End()
+		return
+	}
+
+	if err != nil {
+		span.SetAttributes(AttributeOutcome.String("invalid"), AttributeCode.String(code))
+	} else {
+		span.SetAttributes(AttributeOutcome.String("valid"))
+	}
+	opt.endSpan(span, address, err)
+}
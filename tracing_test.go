@@ -0,0 +1,140 @@
+package emailvalidator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func spans(sr *tracetest.SpanRecorder) map[string]sdktrace.ReadOnlySpan {
+	res := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range sr.Ended() {
+		res[s.Name()] = s
+	}
+
+	return res
+}
+
+func attributes(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	res := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Attributes() {
+		res[kv.Key] = kv.Value
+	}
+
+	return res
+}
+
+func TestTracing(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "signup")
+	_, err := ValidateContext(ctx, "johnsmith@Example.org", TracerProvider(tp), AllowSpecialUse(), CheckMX(time.Millisecond, false))
+	require.NoError(t, err)
+	parent.End()
+
+	got := spans(sr)
+	for _, name := range []string{"emailvalidator.parse", "emailvalidator.syntax", "emailvalidator.data", "emailvalidator.network"} {
+		require.Contains(t, got, name)
+		assert.Equal(t, got["emailvalidator.Validate"].SpanContext().SpanID(), got[name].Parent().SpanID(), name)
+	}
+
+	validate := got["emailvalidator.Validate"]
+	assert.Equal(t, parent.SpanContext().SpanID(), validate.Parent().SpanID())
+	attrs := attributes(validate)
+	assert.Equal(t, "example.org", attrs[AttributeDomain].AsString())
+	assert.Equal(t, "valid", attrs[AttributeOutcome].AsString())
+	assert.NotContains(t, attrs, AttributeAddress)
+
+	mx := got["emailvalidator.check.mx"]
+	require.NotNil(t, mx)
+	assert.Equal(t, got["emailvalidator.network"].SpanContext().SpanID(), mx.Parent().SpanID())
+	assert.Contains(t, attributes(mx), AttributeMXValid)
+}
+
+func TestTracingError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	c := NewMemoryCache(10, 0)
+	_, err := Validate("johnsmith@gmail.invalidtld", TracerProvider(tp), TraceAddress(), ResultCache(c))
+	require.Error(t, err)
+
+	got := spans(sr)
+	validate := got["emailvalidator.Validate"]
+	require.NotNil(t, validate)
+	assert.Equal(t, codes.Error, validate.Status().Code)
+	attrs := attributes(validate)
+	assert.Equal(t, "invalid", attrs[AttributeOutcome].AsString())
+	assert.Equal(t, CheckNameTLD, attrs[AttributeCode].AsString())
+	assert.Equal(t, "johnsmith@gmail.invalidtld", attrs[AttributeAddress].AsString())
+	assert.Equal(t, codes.Error, got["emailvalidator.syntax"].Status().Code)
+	assert.False(t, attributes(got["emailvalidator.cache"])[AttributeCacheHit].AsBool())
+	assert.NotContains(t, got, "emailvalidator.data")
+
+	sr = tracetest.NewSpanRecorder()
+	tp = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	_, err = Validate("invalid", TracerProvider(tp))
+	require.Error(t, err)
+	got = spans(sr)
+	assert.Equal(t, codes.Error, got["emailvalidator.parse"].Status().Code)
+	assert.Equal(t, CodeSyntax, attributes(got["emailvalidator.Validate"])[AttributeCode].AsString())
+}
+
+func TestTracingRedactError(t *testing.T) {
+	deny := []OptionSetter{DenyList(AccessList{Addresses: []string{"john.smith@gmail.com"}}), RejectDenied()}
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	_, err := Validate("John.Smith@gmail.com", append(deny, TracerProvider(tp))...)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "john.smith@gmail.com")
+
+	got := spans(sr)
+	for _, name := range []string{"emailvalidator.Validate", "emailvalidator.syntax"} {
+		s := got[name]
+		require.NotNil(t, s, name)
+		assert.Equal(t, "the address is in the deny list (address ***@gmail.com)", s.Status().Description, name)
+		require.Len(t, s.Events(), 1, name)
+		for _, kv := range s.Events()[0].Attributes {
+			assert.NotContains(t, strings.ToLower(kv.Value.Emit()), "john.smith", name)
+		}
+	}
+
+	sr = tracetest.NewSpanRecorder()
+	tp = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	_, err = Validate("john.smith@gmail.com", append(deny, TracerProvider(tp), TraceAddress())...)
+	require.Error(t, err)
+	assert.Equal(t, err.Error(), spans(sr)["emailvalidator.Validate"].Status().Description)
+}
+
+func TestTracingGlobalProvider(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	// the validation is not traced without a recording span in the context
+	_, err := Validate("johnsmith@gmail.com")
+	require.NoError(t, err)
+	assert.Empty(t, sr.Ended())
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "signup")
+	_, err = ValidateContext(ctx, "johnsmith@gmail.com")
+	require.NoError(t, err)
+	parent.End()
+
+	got := spans(sr)
+	require.Contains(t, got, "emailvalidator.Validate")
+	assert.Equal(t, parent.SpanContext().SpanID(), got["emailvalidator.Validate"].Parent().SpanID())
+}
@@ -0,0 +1,116 @@
+package emailvalidator
+
+import (
+	"context"
+	"strings"
+)
+
+// typoDomains are the popular providers that are checked for typos, with the protected domains. the providers with
+// a short name (like aol.com and qq.com) are not in the list, a typo of a short name is often another real domain
+var typoDomains = []string{
+	"gmail.com",
+	"googlemail.com",
+	"yahoo.com",
+	"yahoo.co.uk",
+	"yahoo.fr",
+	"hotmail.com",
+	"hotmail.co.uk",
+	"hotmail.fr",
+	"outlook.com",
+	"icloud.com",
+	"protonmail.com",
+	"yandex.ru",
+	"yandex.com",
+	"rambler.ru",
+	"comcast.net",
+	"verizon.net",
+	"orange.fr",
+	"wanadoo.fr",
+	"libero.it",
+	"t-online.de",
+	"seznam.cz",
+	"naver.com",
+	"hanmail.net",
+	"rediffmail.com",
+}
+
+// minTypoLabelLength is the minimum length of the first label of a domain in the typo check
+const minTypoLabelLength = 5
+
+// editDistance returns the optimal string alignment distance of the strings, the number of the inserted, deleted,
+// substituted or transposed (adjacent) characters
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+// suggestDomain returns the popular or protected domain that is one typo away from the domain, or an empty string.
+// the free providers and the protected domains themselves have no suggestion
+func suggestDomain(domain string, protected []string) string {
+	if isFreeProvider(domain) {
+		return ""
+	}
+
+	for i := range protected {
+		if protected[i] == domain {
+			return ""
+		}
+	}
+
+	for _, list := range [][]string{protected, typoDomains} {
+		for _, d := range list {
+			if strings.IndexByte(d, '.') < minTypoLabelLength || abs(len(d)-len(domain)) > 1 {
+				continue
+			}
+			if editDistance(d, domain) == 1 {
+				return d
+			}
+		}
+	}
+
+	return ""
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+func checkTypo(_ context.Context, in *Input, res *ValidationResult) error {
+	res.Typo = ValidationStateFalse
+	if isAllowed(res) {
+		return nil
+	}
+
+	if d := suggestDomain(in.Domain, in.opt.protectedDomains); d != "" {
+		res.Typo = ValidationStateTrue
+		res.Suggestion = d
+	}
+
+	return nil
+}
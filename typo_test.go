@@ -0,0 +1,52 @@
+package emailvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("gmail.com", "gmail.com"))
+	assert.Equal(t, 1, editDistance("gmial.com", "gmail.com"))
+	assert.Equal(t, 1, editDistance("gmail.con", "gmail.com"))
+	assert.Equal(t, 1, editDistance("gmai.com", "gmail.com"))
+	assert.Equal(t, 1, editDistance("gmaill.com", "gmail.com"))
+	assert.Equal(t, 2, editDistance("gmx.net", "gmx.de"))
+	assert.Equal(t, 9, editDistance("", "gmail.com"))
+}
+
+func TestTypo(t *testing.T) {
+	for domain, want := range map[string]string{
+		"gmial.com":    "gmail.com",
+		"gmail.co":     "gmail.com",
+		"hotmal.com":   "hotmail.com",
+		"yahoo.cm":     "yahoo.com",
+		"outlok.com":   "outlook.com",
+		"paypall.com":  "paypal.com",
+		"gmail.com":    "",
+		"mail.com":     "",
+		"paypal.com":   "",
+		"aol.co":       "",
+		"example.org":  "",
+		"company.com":  "",
+		"yahoo.co.uk":  "",
+		"protonme.com": "",
+	} {
+		t.Run(domain, func(t *testing.T) {
+			res, err := Validate("johnsmith@"+domain, ProtectedDomains("paypal.com"))
+			require.NoError(t, err)
+			assert.Equal(t, want, res.Suggestion)
+			if want == "" {
+				assert.Equal(t, ValidationStateFalse, res.Typo)
+				return
+			}
+			assert.Equal(t, ValidationStateTrue, res.Typo)
+		})
+	}
+
+	res, err := Validate("johnsmith@gmial.com", AllowList(AccessList{Domains: []string{"gmial.com"}}))
+	require.NoError(t, err)
+	assert.Equal(t, ValidationStateFalse, res.Typo)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net"
+	"log/slog"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ValidationState is used to describe the validation result
@@ -26,7 +28,35 @@ type ValidationResult struct {
 	FreeProvider ValidationState `json:"free_provider"`
 	Disposable   ValidationState `json:"disposable"`
 	MXValidation ValidationState `json:"mx_validation"`
-	BlackList    ValidationState `json:"black_list"`
+	// MXStatus is the outcome of the MX check when it is performed, the MXError is the error of the lookup. the
+	// MXError is not in the json, so it is not kept in the cache either
+	MXStatus  MXStatus        `json:"mx_status,omitempty"`
+	MXError   error           `json:"-"`
+	BlackList ValidationState `json:"black_list"`
+	// BlackListCategory is the category of the role account when the BlackList is true
+	BlackListCategory RoleCategory `json:"black_list_category,omitempty"`
+	// Gibberish is true when the user name looks randomly generated, the GibberishScore is the probability
+	Gibberish      ValidationState `json:"gibberish"`
+	GibberishScore float64         `json:"gibberish_score"`
+	// MixedScript is true when the user name or a domain label mix characters from different scripts
+	MixedScript ValidationState `json:"mixed_script"`
+	// Homograph is true when the domain looks like a protected domain or a free provider, the ImitatedDomain is
+	// the domain it looks like
+	Homograph      ValidationState `json:"homograph"`
+	ImitatedDomain string          `json:"imitated_domain,omitempty"`
+	// Typo is true when the domain is one typo away from a popular provider or a protected domain, like gmial.com,
+	// the Suggestion is the domain that was probably meant
+	Typo       ValidationState `json:"typo"`
+	Suggestion string          `json:"suggestion,omitempty"`
+	// Denied is true when the address is in the deny list, the ListMatch is the entry in the allow or deny list
+	// that matched the address
+	Denied    ValidationState `json:"denied"`
+	ListMatch *ListMatch      `json:"list_match,omitempty"`
+	// SpecialUse is true when the domain is a special-use domain (RFC 6761), like example.com
+	SpecialUse     ValidationState `json:"special_use"`
+	SpecialUseKind SpecialUseKind  `json:"special_use_kind,omitempty"`
+	// TLD is the metadata of the top level domain
+	TLD *TLDInfo `json:"tld,omitempty"`
 }
 
 // Options internally used to handle the options, use OptionSetter to change the option
@@ -34,25 +64,27 @@ type Options struct {
 	mxValidation        int
 	mxValidationTimeout time.Duration
 	mxForce             int
+	gibberishThreshold  float64
+	gibberishMinLength  int
+	protectedDomains    []string
+	allowList           []AccessList
+	denyList            []AccessList
+	rejectDenied        bool
+	specialUse          map[SpecialUseKind]bool
+	concurrency         int
+	unordered           bool
+	mxCache             *mxCache
+	cache               Cache
+	observer            Observer
+	tracer              trace.Tracer
+	traceAddress        bool
+	logger              *slog.Logger
+	logLocalPart        LocalPart
 }
 
 // OptionSetter is used to handle options in the file
 type OptionSetter func(*Options) error
 
-// MarshalJSON json transform for the value
-func (v ValidationState) MarshalJSON() ([]byte, error) {
-	switch v {
-	case ValidationStateNotChecked:
-		return []byte("null"), nil
-	case ValidationStateFalse:
-		return []byte("false"), nil
-	case ValidationStateTrue:
-		return []byte("true"), nil
-	}
-
-	return nil, fmt.Errorf("value %d not supported", v)
-}
-
 // CheckMX add the checking the me record to validation. if the force is active then it check the MX even if the
 // disposable or free provider is detected.
 func CheckMX(timeout time.Duration, forceCheck bool) OptionSetter {
@@ -72,18 +104,18 @@ func CheckMX(timeout time.Duration, forceCheck bool) OptionSetter {
 func isDisposable(domain string) bool {
 	parts := strings.Split(domain, ".")
 	if len(parts) > 2 {
-		return wildDisposableDomain[strings.Join(parts[len(parts)-2:], ".")]
+		return wildDisposableDomain.contains(strings.Join(parts[len(parts)-2:], "."))
 	}
 
-	return disposableDomain[domain]
+	return disposableDomain.contains(domain)
 }
 
 func isFreeProvider(domain string) bool {
-	return freeProvider[domain]
+	return freeProvider.contains(domain)
 }
 
 func isValidTLD(in string) bool {
-	return tlds[in]
+	return tlds.contains(in)
 }
 
 func extractEmailParts(email string) (username string, domain string, tld string, err error) {
@@ -102,104 +134,40 @@ func extractEmailParts(email string) (username string, domain string, tld string
 	return
 }
 
+// validateMx looks up the MX records of the domain, the error is a *MXLookupError with the status of the lookup
 func validateMx(ctx context.Context, domain string) error {
-	r := net.Resolver{}
-	_, err := r.LookupMX(ctx, domain)
-	if err != nil {
-		// Based on RFC5321 if no MX record found, we should fallback to A or AAAA record check
-		if _, err := r.LookupHost(ctx, domain); err != nil {
-			return errors.New("lockup failed")
+	records, err := dnsResolver.LookupMX(ctx, domain)
+	if err == nil && nullMX(records) {
+		return &MXLookupError{Status: MXStatusNoRecords, Domain: domain, Err: errNullMX}
+	}
+	if err == nil && len(records) > 0 {
+		return nil
+	}
+	if err != nil && !isNotFound(err) {
+		return newMXLookupError(domain, err)
+	}
+
+	// Based on RFC5321 if no MX record found, we should fallback to A or AAAA record check
+	if _, err := dnsResolver.LookupHost(ctx, domain); err != nil {
+		if !isNotFound(err) {
+			return newMXLookupError(domain, err)
 		}
+		exists, nsErr := zoneExists(ctx, domain)
+		if nsErr != nil {
+			return newMXLookupError(domain, nsErr)
+		}
+		if exists {
+			return &MXLookupError{Status: MXStatusNoRecords, Domain: domain, Err: err}
+		}
+		return &MXLookupError{Status: MXStatusNXDomain, Domain: domain, Err: err}
 	}
 	return nil
 }
 
-func isBlackList(u string) bool {
-	return blackList[strings.ToLower(u)]
-}
-
 // ValidateContext try to validate the email address, the context version, this context used for any
 // extra validation used in the library (like MX validation)
 func ValidateContext(ctx context.Context, address string, opts ...OptionSetter) (*ValidationResult, error) {
-	opt := &Options{}
-	for i := range opts {
-		if err := opts[i](opt); err != nil {
-			return nil, err
-		}
-	}
-
-	username, domain, tld, err := extractEmailParts(address)
-	if err != nil {
-		return nil, err
-	}
-
-	/*
-		In addition to restrictions on syntax, there is a length limit on
-		email addresses.  That limit is a maximum of 64 characters (octets)
-		in the "local part" (before the "@") and a maximum of 255 characters
-		(octets) in the domain part (after the "@") for a total length of 320
-		characters. However, there is a restriction in RFC 2821 on the length of an
-		address in MAIL and RCPT commands of 256 characters.  Since addresses
-		that do not fit in those fields are not normally useful, the upper
-		limit on address lengths should normally be considered to be 256.
-	*/
-	/*
-		https://www.rfc-editor.org/errata/eid1690
-		I believe erratum ID 1003 is slightly wrong. RFC 2821 places a 256 character
-		limit on the forward-path. But a path is defined as
-		Path = "<" [ A-d-l ":" ] Mailbox ">"
-		So the forward-path will contain at least a pair of angle brackets in addition to the Mailbox.
-		This limits the Mailbox (i.e. the email address) to 254 characters.
-	*/
-	if len(address) > 254 {
-		return nil, errors.New("maximum email address size is 254")
-	}
-
-	if len(username) > 64 {
-		return nil, errors.New("maximum user name (before @) length is 64")
-	}
-
-	if !isValidTLD(tld) {
-		return nil, fmt.Errorf("the %s is not valid tld", tld)
-	}
-
-	if err := isValidUserName(username, domain); err != nil {
-		return nil, err
-	}
-
-	res := ValidationResult{
-		Disposable:   ValidationStateFalse,
-		FreeProvider: ValidationStateFalse,
-		BlackList:    ValidationStateFalse,
-	}
-
-	var dispOrFree bool
-	if isDisposable(domain) {
-		res.Disposable = ValidationStateTrue
-		dispOrFree = true
-	}
-
-	if isFreeProvider(domain) {
-		res.FreeProvider = ValidationStateTrue
-		dispOrFree = true
-	}
-
-	if isBlackList(username) {
-		res.BlackList = ValidationStateTrue
-	}
-
-	mxCheck := opt.mxValidation == 1 && (!dispOrFree || opt.mxForce == 1)
-
-	if mxCheck {
-		res.MXValidation = ValidationStateTrue
-		ctx, cancel := context.WithTimeout(ctx, opt.mxValidationTimeout)
-		defer cancel()
-		if err := validateMx(ctx, domain); err != nil {
-			res.MXValidation = ValidationStateFalse
-		}
-	}
-
-	return &res, nil
+	return defaultValidator.ValidateContext(ctx, address, opts...)
 }
 
 // Validate is for validating single email
@@ -173,10 +173,17 @@ func TestJSONResult(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, map[string]interface{}{
-		"free_provider": nil,
-		"disposable":    false,
-		"mx_validation": true,
-		"black_list":    nil,
+		"free_provider":   nil,
+		"disposable":      false,
+		"mx_validation":   true,
+		"black_list":      nil,
+		"gibberish":       nil,
+		"gibberish_score": float64(0),
+		"mixed_script":    nil,
+		"homograph":       nil,
+		"typo":            nil,
+		"denied":          nil,
+		"special_use":     nil,
 	}, m)
 
 	res = ValidationResult{